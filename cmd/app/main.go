@@ -1,47 +1,180 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/exchange"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/metrics"
+	"github.com/dannamer/JavaCode-test/internal/model"
 	"github.com/dannamer/JavaCode-test/internal/repository/postgresql"
 	"github.com/dannamer/JavaCode-test/internal/service"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 )
 
 func main() {
+	appLog, err := logger.New(logger.NewConfig())
+	if err != nil {
+		log.Fatal("Invalid logger configuration: ", err)
+	}
 
 	config, err := postgresql.NewConfig()
 	if err != nil {
-		log.Fatal("Ошибка конфигурации PostgreSQL:", err)
+		appLog.Fatalf("Invalid PostgreSQL configuration: %v", err)
+	}
+
+	customCurrencies, err := model.ParseCurrencies(os.Getenv("CUSTOM_CURRENCIES"))
+	if err != nil {
+		appLog.Fatalf("Invalid CUSTOM_CURRENCIES: %v", err)
+	}
+	for _, currency := range customCurrencies {
+		if err := model.RegisterCurrency(currency); err != nil {
+			appLog.Fatalf("Invalid CUSTOM_CURRENCIES: %v", err)
+		}
+	}
+
+	reconciliationConfig, err := service.NewReconciliationConfig()
+	if err != nil {
+		appLog.Fatalf("Invalid reconciliation configuration: %v", err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		os.Exit(runReconcile(appLog, config, reconciliationConfig, os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "wallets" {
+		os.Exit(runWallets(appLog, config, os.Args[2:]))
+	}
+
+	serverConfig, err := api.NewServerConfig()
+	if err != nil {
+		appLog.Fatalf("Invalid HTTP server configuration: %v", err)
+	}
+
+	tracingConfig := tracing.NewConfig()
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingConfig)
+	if err != nil {
+		appLog.Fatalf("Invalid tracing configuration: %v", err)
 	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			appLog.Errorf("Failed to flush traces: %v", err)
+		}
+	}()
 
-	runDBMigration(os.Getenv("MIGRATION_URL"), config.GetDSN())
+	migrationVersion := runDBMigration(appLog, os.Getenv("MIGRATION_URL"), config.GetDSN())
 
-	postgres, err := postgresql.NewPostgres(*config)
+	postgres, err := postgresql.NewPostgres(*config, postgresql.WithTracer(tracing.QueryTracer{}))
 	if err != nil {
-		log.Fatal("Ошибка подключения к базе данных:", err)
+		appLog.Fatalf("Failed to connect to the database: %v", err)
 	}
+	defer postgres.Pool.Close()
 
-	repo := postgresql.NewWalletRepo(postgres.Pool)
-	serv := service.NewWalletService(&repo)
-	server := api.NewWalletHandler(&serv)
+	limitsConfig, err := service.NewLimitsConfig()
+	if err != nil {
+		appLog.Fatalf("Invalid limits configuration: %v", err)
+	}
+
+	repo := postgresql.NewWalletRepo(postgres.Pool, appLog)
+	repo.DefaultLimits = limitsConfig.Defaults
+	serv := service.NewWalletService(&repo, appLog)
+	server := api.NewWalletHandler(&serv, appLog)
+
+	rates, err := exchange.LoadStaticRates(os.Getenv("EXCHANGE_RATES_FILE"))
+	if err != nil {
+		appLog.Fatalf("Failed to load exchange rates: %v", err)
+	}
+	exchangeServ := service.NewExchangeService(&repo, rates, appLog)
+	exchangeHandler := api.NewExchangeHandler(&exchangeServ, appLog)
 
-	server.RunServer()
+	holdConfig, err := service.NewHoldConfig()
+	if err != nil {
+		appLog.Fatalf("Invalid hold configuration: %v", err)
+	}
+	holdServ := service.NewHoldService(&repo, appLog)
+	holdHandler := api.NewHoldHandler(&holdServ, appLog)
+
+	statusServ := service.NewWalletStatusService(&repo, appLog)
+	statusHandler := api.NewWalletStatusHandler(&statusServ, appLog)
+
+	limitsServ := service.NewLimitsService(&repo, limitsConfig.Defaults, appLog)
+	limitsHandler := api.NewLimitsHandler(&limitsServ, appLog)
+
+	bulkServ := service.NewBulkService(&repo, limitsConfig.Defaults, appLog)
+	bulkHandler := api.NewBulkHandler(&bulkServ, appLog)
+
+	statementServ := service.NewStatementService(&repo, appLog)
+	statementHandler := api.NewStatementHandler(&statementServ, appLog)
+
+	snapshotConfig, err := service.NewSnapshotConfig()
+	if err != nil {
+		appLog.Fatalf("Invalid balance snapshot configuration: %v", err)
+	}
+
+	healthRepo := postgresql.NewHealthRepo(postgres.Pool, appLog)
+	healthServ := service.NewHealthService(&healthRepo, migrationVersion)
+	health := api.NewHealthHandler(&healthServ)
+
+	prometheus.MustRegister(metrics.NewPoolCollector(postgres.Pool))
+
+	router := server.Router()
+	router.Use(otelmux.Middleware(tracingConfig.ServiceName), api.RequestID, api.AccessLog(appLog), metrics.InstrumentHandler)
+	health.Register(router)
+	exchangeHandler.Register(router)
+	holdHandler.Register(router)
+	statusHandler.Register(router)
+	limitsHandler.Register(router)
+	bulkHandler.Register(router)
+	statementHandler.Register(router)
+	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go holdServ.RunExpiry(ctx, holdConfig.ExpiryInterval)
+
+	if reconciliationConfig.Interval > 0 {
+		reconciliationRepo := postgresql.NewReconciliationRepo(postgres.Pool, appLog)
+		reconciliation := service.NewReconciliationService(&reconciliationRepo, appLog, reconciliationConfig.BatchSize)
+		go reconciliation.RunSchedule(ctx, reconciliationConfig.Interval)
+	}
+
+	if snapshotConfig.Interval > 0 {
+		snapshotServ := service.NewSnapshotService(&repo, appLog, snapshotConfig.BatchSize)
+		go snapshotServ.RunSchedule(ctx, snapshotConfig.Interval)
+	}
+
+	if err := api.RunServer(ctx, *serverConfig, router, appLog, health.MarkShuttingDown); err != nil {
+		appLog.Errorf("Error running server: %v", err)
+	}
 }
 
-func runDBMigration(migrationURL string, dbSource string) {
+func runDBMigration(log *logrus.Logger, migrationURL string, dbSource string) uint {
 	migration, err := migrate.New(migrationURL, dbSource)
 	if err != nil {
-		log.Fatal("cannot create a new migrate instance", err)
+		log.Fatalf("Cannot create a new migrate instance: %v", err)
 	}
 
 	if err = migration.Up(); err != nil && err != migrate.ErrNoChange {
-		log.Fatal("failed to run migrate up:", err)
+		log.Fatalf("Failed to run migrate up: %v", err)
+	}
+
+	version, _, err := migration.Version()
+	if err != nil {
+		log.Fatalf("Failed to read migration version: %v", err)
 	}
-	log.Println("db migrated successfully")
+	log.WithField("version", version).Info("DB migrated successfully")
+	return version
 }
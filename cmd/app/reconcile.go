@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/repository/postgresql"
+	"github.com/dannamer/JavaCode-test/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+// exitDrift сообщает вызывающему (cron, CI), что сверка нашла расхождения.
+const exitDrift = 2
+
+// runReconcile выполняет разовую сверку балансов и возвращает код завершения процесса.
+func runReconcile(log *logrus.Logger, config *postgresql.Config, reconciliationConfig *service.ReconciliationConfig, args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	reportPath := flags.String("report", "", "write the JSON report to this file, - for stdout")
+	batchSize := flags.Int("batch-size", reconciliationConfig.BatchSize, "wallets checked per query")
+	flags.Parse(args)
+
+	postgres, err := postgresql.NewPostgres(*config, postgresql.WithMaxPoolSize(2))
+	if err != nil {
+		log.Errorf("Failed to connect to the database: %v", err)
+		return 1
+	}
+	defer postgres.Pool.Close()
+
+	repo := postgresql.NewReconciliationRepo(postgres.Pool, log)
+	serv := service.NewReconciliationService(&repo, log, *batchSize)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, err := serv.Reconcile(ctx)
+	if err != nil {
+		log.Errorf("Reconciliation failed: %v", err)
+		return 1
+	}
+
+	if *reportPath != "" {
+		if err := writeReport(*reportPath, report); err != nil {
+			log.Errorf("Failed to write reconciliation report: %v", err)
+			return 1
+		}
+	}
+
+	if len(report.Mismatches) > 0 {
+		return exitDrift
+	}
+	return 0
+}
+
+func writeReport(path string, report model.ReconciliationReport) error {
+	if path == "-" {
+		return encodeReport(os.Stdout, report)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := encodeReport(file, report); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func encodeReport(out io.Writer, report model.ReconciliationReport) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/repository/postgresql"
+	"github.com/dannamer/JavaCode-test/internal/service"
+	"github.com/sirupsen/logrus"
+)
+
+const walletsUsage = "usage: wallets import|export [-format csv|ndjson] [-file path]"
+
+// runWallets выполняет массовый импорт или экспорт кошельков и возвращает код завершения процесса.
+// Импорт пишет в лог каждую отклонённую строку с её номером.
+func runWallets(log *logrus.Logger, config *postgresql.Config, args []string) int {
+	if len(args) == 0 || (args[0] != "import" && args[0] != "export") {
+		fmt.Fprintln(os.Stderr, walletsUsage)
+		return 1
+	}
+	command := args[0]
+
+	flags := flag.NewFlagSet("wallets "+command, flag.ExitOnError)
+	format := flags.String("format", string(model.BulkCSV), "stream format: csv or ndjson")
+	path := flags.String("file", "-", "file to read or write, - for stdin or stdout")
+	flags.Parse(args[1:])
+
+	if !model.BulkFormat(*format).Valid() {
+		fmt.Fprintln(os.Stderr, walletsUsage)
+		return 1
+	}
+	if command == "export" && *path == "-" {
+		// Выгрузка идёт в stdout, поэтому лог уводится в stderr.
+		log.SetOutput(os.Stderr)
+	}
+
+	limitsConfig, err := service.NewLimitsConfig()
+	if err != nil {
+		log.Errorf("Invalid limits configuration: %v", err)
+		return 1
+	}
+
+	postgres, err := postgresql.NewPostgres(*config, postgresql.WithMaxPoolSize(2))
+	if err != nil {
+		log.Errorf("Failed to connect to the database: %v", err)
+		return 1
+	}
+	defer postgres.Pool.Close()
+
+	repo := postgresql.NewWalletRepo(postgres.Pool, log)
+	serv := service.NewBulkService(&repo, limitsConfig.Defaults, log)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if command == "import" {
+		return importWallets(ctx, log, &serv, model.BulkFormat(*format), *path)
+	}
+	return exportWallets(ctx, log, &serv, model.BulkFormat(*format), *path)
+}
+
+func importWallets(ctx context.Context, log *logrus.Logger, serv *service.BulkService, format model.BulkFormat, path string) int {
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Errorf("Failed to open import file: %v", err)
+			return 1
+		}
+		defer file.Close()
+		in = file
+	}
+
+	result, err := serv.Import(ctx, format, in)
+	var invalid *model.WalletImportError
+	if errors.As(err, &invalid) {
+		for _, line := range invalid.Errors {
+			log.Errorf("line %d: %s", line.Line, line.Message)
+		}
+		if invalid.Truncated {
+			log.Errorf("More than %d invalid lines, validation stopped", model.MaxImportErrors)
+		}
+		return 1
+	}
+	if err != nil {
+		log.Errorf("Wallet import failed: %v", err)
+		return 1
+	}
+
+	log.Infof("Imported %d wallets", result.Imported)
+	return 0
+}
+
+func exportWallets(ctx context.Context, log *logrus.Logger, serv *service.BulkService, format model.BulkFormat, path string) int {
+	if path == "-" {
+		if _, err := serv.Export(ctx, format, os.Stdout); err != nil {
+			log.Errorf("Wallet export failed: %v", err)
+			return 1
+		}
+		return 0
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		log.Errorf("Failed to create export file: %v", err)
+		return 1
+	}
+	if _, err := serv.Export(ctx, format, file); err != nil {
+		file.Close()
+		log.Errorf("Wallet export failed: %v", err)
+		return 1
+	}
+	if err := file.Close(); err != nil {
+		log.Errorf("Failed to write export file: %v", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+)
+
+// batchItemResponse дополняет результат операции пакета её ошибкой в том же виде, что и ответ на одиночную операцию.
+type batchItemResponse struct {
+	model.BatchItemResult
+	Error *model.Response `json:"error,omitempty"`
+}
+
+type batchResponse struct {
+	model.BatchResult
+	Items []batchItemResponse `json:"items"`
+}
+
+func newBatchResponse(result model.BatchResult) batchResponse {
+	response := batchResponse{BatchResult: result, Items: make([]batchItemResponse, len(result.Items))}
+	for i, item := range result.Items {
+		response.Items[i] = batchItemResponse{BatchItemResult: item}
+		if item.Err != nil {
+			itemError := errorResponse(item.Err)
+			response.Items[i].Error = &itemError
+		}
+	}
+	return response
+}
+
+// WalletBatch отвечает 200 с результатами операций; отклонённый атомарный пакет получает
+// статус ошибки, из-за которой он откатился. Заголовок Idempotency-Key относится ко всему пакету.
+func (h *WalletHandlers) WalletBatch(w http.ResponseWriter, r *http.Request) {
+	var request model.BatchRequest
+
+	if err := decodeBody(r, &request); err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestBody,
+		})
+		return
+	}
+
+	request.IdempotencyKey = r.Header.Get(IdempotencyKeyHeader)
+
+	if !request.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestData,
+		})
+		return
+	}
+	if index, invalid := request.InvalidItem(); invalid {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf(model.StatusInvalidBatchItem, index),
+		})
+		return
+	}
+
+	result, err := h.WalletService.WalletBatch(r.Context(), request)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	response := model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusBatchSuccess,
+		Data:    newBatchResponse(result),
+	}
+	if rejected, ok := result.Rejected(); ok {
+		cause := errorResponse(rejected.Err)
+		response.Status = cause.Status
+		response.Message = fmt.Sprintf(model.StatusBatchRejected, rejected.Index, cause.Message)
+	}
+	sendResponse(w, r, response)
+}
@@ -0,0 +1,156 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalletBatch_BestEffortReturnsItemErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	request := model.BatchRequest{Mode: model.BatchBestEffort, Transactions: []model.Transaction{
+		{WalletID: uuid.New(), OperationType: model.Deposit, Amount: decimal.NewFromInt32(10)},
+		{WalletID: uuid.New(), OperationType: model.Withdraw, Amount: decimal.NewFromInt32(10)},
+	}}
+	record := model.TransactionRecord{UUID: uuid.New()}
+
+	mockWalletService.EXPECT().WalletBatch(gomock.Any(), request).Return(model.BatchResult{
+		Mode:      model.BatchBestEffort,
+		Succeeded: 1,
+		Failed:    1,
+		Items: []model.BatchItemResult{
+			{Index: 0, Status: model.BatchItemSucceeded, Transaction: &record},
+			{Index: 1, Status: model.BatchItemFailed, Err: model.ErrInsufficientFunds},
+		},
+	}, nil)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet/batch", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.WalletBatch(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	items := resp.Data.(map[string]interface{})["items"].([]interface{})
+	assert.Len(t, items, 2)
+	assert.Equal(t, record.UUID.String(), items[0].(map[string]interface{})["transaction"].(map[string]interface{})["uuid"])
+	itemError := items[1].(map[string]interface{})["error"].(map[string]interface{})
+	assert.Equal(t, float64(http.StatusUnprocessableEntity), itemError["status"])
+	assert.Equal(t, model.StatusInsufficientFunds, itemError["message"])
+}
+
+func TestWalletBatch_AtomicRejectedUsesCauseStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	request := model.BatchRequest{Transactions: []model.Transaction{
+		{WalletID: uuid.New(), OperationType: model.Deposit, Amount: decimal.NewFromInt32(10)},
+		{WalletID: uuid.New(), OperationType: model.Withdraw, Amount: decimal.NewFromInt32(10)},
+	}}
+
+	mockWalletService.EXPECT().WalletBatch(gomock.Any(), request).Return(model.BatchResult{
+		Mode:   model.BatchAtomic,
+		Failed: 1,
+		Items: []model.BatchItemResult{
+			{Index: 0, Status: model.BatchItemRolledBack},
+			{Index: 1, Status: model.BatchItemFailed, Err: model.ErrInsufficientFunds},
+		},
+	}, nil)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet/batch", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.WalletBatch(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(model.StatusBatchRejected, 1, model.StatusInsufficientFunds), resp.Message)
+}
+
+func TestWalletBatch_InvalidItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	mockWalletService.EXPECT().WalletBatch(gomock.Any(), gomock.Any()).Times(0)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	request := model.BatchRequest{Mode: model.BatchBestEffort, Transactions: []model.Transaction{
+		{WalletID: uuid.New(), OperationType: model.Deposit, Amount: decimal.NewFromInt32(10)},
+		{WalletID: uuid.New(), OperationType: model.Deposit, Amount: decimal.NewFromInt32(-10)},
+	}}
+	reqBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet/batch", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.WalletBatch(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(model.StatusInvalidBatchItem, 1), resp.Message)
+}
+
+func TestWalletBatch_IdempotencyKeyConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	request := model.BatchRequest{Transactions: []model.Transaction{
+		{WalletID: uuid.New(), OperationType: model.Deposit, Amount: decimal.NewFromInt32(10)},
+	}}
+	reqBody, _ := json.Marshal(request)
+
+	// Ключ из заголовка доходит до сервиса вместе с пакетом.
+	request.IdempotencyKey = "batch-1"
+	mockWalletService.EXPECT().WalletBatch(gomock.Any(), request).Return(model.BatchResult{}, model.ErrIdempotencyKeyConflict)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet/batch", bytes.NewBuffer(reqBody))
+	req.Header.Set(api.IdempotencyKeyHeader, "batch-1")
+	rr := httptest.NewRecorder()
+
+	handler.WalletBatch(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusIdempotencyKeyConflict, resp.Message)
+}
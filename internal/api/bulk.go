@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// BulkPathPrefix — маршруты массового импорта и экспорта; для них действует BulkTimeout
+// вместо RequestTimeout.
+const BulkPathPrefix = "/api/v1/admin/bulk/"
+
+type BulkService interface {
+	Import(ctx context.Context, format model.BulkFormat, body io.Reader) (model.WalletImportResult, error)
+	Export(ctx context.Context, format model.BulkFormat, w io.Writer) (int64, error)
+}
+
+type BulkHandlers struct {
+	BulkService
+	log *logrus.Logger
+}
+
+func NewBulkHandler(bulkService BulkService, log *logrus.Logger) BulkHandlers {
+	return BulkHandlers{BulkService: bulkService, log: log}
+}
+
+func (h *BulkHandlers) Register(r *mux.Router) {
+	r.HandleFunc(BulkPathPrefix+"wallets/import", h.ImportWallets).Methods("POST")
+	r.HandleFunc(BulkPathPrefix+"wallets/export", h.ExportWallets).Methods("GET")
+}
+
+func (h *BulkHandlers) sendError(w http.ResponseWriter, r *http.Request, err error) {
+	sendError(w, r, logger.FromContext(r.Context(), h.log), err)
+}
+
+// parseBulkFormat читает ?format=csv|ndjson; по умолчанию CSV.
+func parseBulkFormat(r *http.Request) (model.BulkFormat, bool) {
+	format := model.BulkFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		return model.BulkCSV, true
+	}
+	return format, format.Valid()
+}
+
+// ImportWallets принимает тело запроса потоком; при ошибках в строках ничего не импортируется,
+// а в data возвращается список строк с номерами.
+func (h *BulkHandlers) ImportWallets(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseBulkFormat(r)
+	if !ok {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidBulkFormat,
+		})
+		return
+	}
+
+	result, err := h.Import(r.Context(), format, r.Body)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusWalletsImported,
+		Data:    result,
+	})
+}
+
+// ExportWallets отдаёт таблицу кошельков файлом по мере выгрузки из базы.
+func (h *BulkHandlers) ExportWallets(w http.ResponseWriter, r *http.Request) {
+	format, ok := parseBulkFormat(r)
+	if !ok {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidBulkFormat,
+		})
+		return
+	}
+
+	contentType, filename := "text/csv", "wallets.csv"
+	if format == model.BulkNDJSON {
+		contentType, filename = "application/x-ndjson", "wallets.ndjson"
+	}
+	streamResponse(w, r, logger.FromContext(r.Context(), h.log), contentType, filename, func(out io.Writer) error {
+		_, err := h.Export(r.Context(), format, out)
+		return err
+	})
+}
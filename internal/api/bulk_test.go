@@ -0,0 +1,103 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportWallets_RejectedLines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBulkService := mock.NewMockBulkService(ctrl)
+	mockBulkService.EXPECT().Import(gomock.Any(), model.BulkNDJSON, gomock.Any()).
+		Return(model.WalletImportResult{}, &model.WalletImportError{Errors: []model.ImportLineError{{Line: 3, Message: "invalid balance \"x\""}}})
+
+	handler := api.NewBulkHandler(mockBulkService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodPost, api.BulkPathPrefix+"wallets/import?format=ndjson", strings.NewReader("{}"))
+	rr := httptest.NewRecorder()
+
+	handler.ImportWallets(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var resp struct {
+		Message string                  `json:"message"`
+		Data    model.WalletImportError `json:"data"`
+	}
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.ImportLineError{{Line: 3, Message: "invalid balance \"x\""}}, resp.Data.Errors)
+}
+
+func TestImportWallets_InvalidFormat(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBulkService := mock.NewMockBulkService(ctrl)
+	mockBulkService.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	handler := api.NewBulkHandler(mockBulkService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodPost, api.BulkPathPrefix+"wallets/import?format=xml", strings.NewReader(""))
+	rr := httptest.NewRecorder()
+
+	handler.ImportWallets(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestExportWallets_StreamsCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBulkService := mock.NewMockBulkService(ctrl)
+	mockBulkService.EXPECT().Export(gomock.Any(), model.BulkCSV, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ model.BulkFormat, w io.Writer) (int64, error) {
+			_, err := io.WriteString(w, "uuid,owner_id,balance,currency,status,created_at\n")
+			return 0, err
+		})
+
+	handler := api.NewBulkHandler(mockBulkService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, api.BulkPathPrefix+"wallets/export", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportWallets(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(rr.Body.String(), "uuid,owner_id"))
+}
+
+func TestExportWallets_ErrorBeforeData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBulkService := mock.NewMockBulkService(ctrl)
+	mockBulkService.EXPECT().Export(gomock.Any(), model.BulkNDJSON, gomock.Any()).Return(int64(0), errors.New("connection refused"))
+
+	handler := api.NewBulkHandler(mockBulkService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, api.BulkPathPrefix+"wallets/export?format=ndjson", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ExportWallets(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+}
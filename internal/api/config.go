@@ -0,0 +1,65 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+type ServerConfig struct {
+	Addr            string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+	RequestTimeout  time.Duration
+	// BulkTimeout заменяет RequestTimeout и дедлайны соединения для потоковых маршрутов:
+	// массовых импорта и экспорта и выписок.
+	BulkTimeout time.Duration
+}
+
+func NewServerConfig() (*ServerConfig, error) {
+	config := &ServerConfig{
+		Addr:            ":8080",
+		ReadTimeout:     5 * time.Second,
+		WriteTimeout:    10 * time.Second,
+		IdleTimeout:     60 * time.Second,
+		ShutdownTimeout: 15 * time.Second,
+		RequestTimeout:  5 * time.Second,
+		BulkTimeout:     10 * time.Minute,
+	}
+
+	if addr := os.Getenv("HTTP_ADDR"); addr != "" {
+		config.Addr = addr
+	}
+
+	durations := map[string]*time.Duration{
+		"HTTP_READ_TIMEOUT":     &config.ReadTimeout,
+		"HTTP_WRITE_TIMEOUT":    &config.WriteTimeout,
+		"HTTP_IDLE_TIMEOUT":     &config.IdleTimeout,
+		"HTTP_SHUTDOWN_TIMEOUT": &config.ShutdownTimeout,
+		"HTTP_REQUEST_TIMEOUT":  &config.RequestTimeout,
+		"HTTP_BULK_TIMEOUT":     &config.BulkTimeout,
+	}
+	for name, target := range durations {
+		if err := lookupDuration(name, target); err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+func lookupDuration(name string, target *time.Duration) error {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return nil
+	}
+
+	value, err := time.ParseDuration(raw)
+	if err != nil || value <= 0 {
+		return fmt.Errorf("invalid duration in %s: %q", name, raw)
+	}
+	*target = value
+	return nil
+}
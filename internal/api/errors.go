@@ -0,0 +1,181 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+// StatusClientClosedRequest — нестандартный статус nginx для запросов, отменённых клиентом.
+const StatusClientClosedRequest = 499
+
+// errorResponse — единственное место, где доменные ошибки сопоставляются HTTP-статусам.
+func errorResponse(err error) model.Response {
+	var (
+		notFound      *model.WalletNotFoundError
+		limitExceeded *model.LimitExceededError
+		invalidImport *model.WalletImportError
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		return model.Response{
+			Status:  http.StatusNotFound,
+			Message: fmt.Sprintf(model.StatusWalletNotFound, notFound.UUID),
+		}
+	case errors.Is(err, model.ErrWalletNotFound):
+		return model.Response{
+			Status:  http.StatusNotFound,
+			Message: model.StatusWalletNotFoundGeneric,
+		}
+	case errors.As(err, &limitExceeded):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf(model.StatusLimitExceeded, limitExceeded.Limit),
+			Data:    limitExceeded,
+		}
+	case errors.As(err, &invalidImport):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: fmt.Sprintf(model.StatusImportRejected, len(invalidImport.Errors)),
+			Data:    invalidImport,
+		}
+	case errors.Is(err, model.ErrInsufficientFunds):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusInsufficientFunds,
+		}
+	case errors.Is(err, model.ErrBalanceBeforeCreation):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusBalanceBeforeCreation,
+		}
+	case errors.Is(err, model.ErrCurrencyMismatch):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusCurrencyMismatch,
+		}
+	case errors.Is(err, model.ErrExchangeRateNotFound):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusExchangeRateNotFound,
+		}
+	case errors.Is(err, model.ErrInvalidExchange):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusInvalidExchange,
+		}
+	case errors.Is(err, model.ErrHoldNotFound):
+		return model.Response{
+			Status:  http.StatusNotFound,
+			Message: model.StatusHoldNotFound,
+		}
+	case errors.Is(err, model.ErrHoldNotActive):
+		return model.Response{
+			Status:  http.StatusConflict,
+			Message: model.StatusHoldNotActive,
+		}
+	case errors.Is(err, model.ErrCaptureExceedsHold):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusCaptureExceedsHold,
+		}
+	case errors.Is(err, model.ErrInvalidAmount):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusInvalidAmount,
+		}
+	case errors.Is(err, model.ErrInvalidLimits):
+		return model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidLimits,
+		}
+	case errors.Is(err, model.ErrWalletFrozen):
+		return model.Response{
+			Status:  http.StatusLocked,
+			Message: model.StatusWalletFrozen,
+		}
+	case errors.Is(err, model.ErrWalletClosed):
+		return model.Response{
+			Status:  http.StatusGone,
+			Message: model.StatusWalletClosed,
+		}
+	case errors.Is(err, model.ErrWalletNotEmpty):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusWalletNotEmpty,
+		}
+	case errors.Is(err, model.ErrInvalidStatusChange):
+		return model.Response{
+			Status:  http.StatusConflict,
+			Message: model.StatusInvalidStatusChange,
+		}
+	case errors.Is(err, model.ErrTransactionNotFound):
+		return model.Response{
+			Status:  http.StatusNotFound,
+			Message: model.StatusTransactionNotFound,
+		}
+	case errors.Is(err, model.ErrNotReversible):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusNotReversible,
+		}
+	case errors.Is(err, model.ErrReversalExceedsAmount):
+		return model.Response{
+			Status:  http.StatusUnprocessableEntity,
+			Message: model.StatusReversalExceedsAmount,
+		}
+	case errors.Is(err, model.ErrAlreadyReversed):
+		return model.Response{
+			Status:  http.StatusConflict,
+			Message: model.StatusAlreadyReversed,
+		}
+	case errors.Is(err, model.ErrWalletAlreadyExists):
+		return model.Response{
+			Status:  http.StatusConflict,
+			Message: model.StatusWalletAlreadyExists,
+		}
+	case errors.Is(err, model.ErrIdempotencyKeyConflict):
+		return model.Response{
+			Status:  http.StatusConflict,
+			Message: model.StatusIdempotencyKeyConflict,
+		}
+	case errors.Is(err, model.ErrConflict):
+		return model.Response{
+			Status:  http.StatusConflict,
+			Message: model.StatusConflict,
+		}
+	case errors.Is(err, context.Canceled):
+		return model.Response{
+			Status:  StatusClientClosedRequest,
+			Message: model.StatusRequestCanceled,
+		}
+	case errors.Is(err, context.DeadlineExceeded):
+		return model.Response{
+			Status:  http.StatusServiceUnavailable,
+			Message: model.StatusRequestTimeout,
+		}
+	default:
+		return model.Response{
+			Status:  http.StatusInternalServerError,
+			Message: model.StatusInternalServerError,
+		}
+	}
+}
+
+// sendError отвечает по errorResponse и логирует только ошибки сервера.
+func sendError(w http.ResponseWriter, r *http.Request, log *logrus.Entry, err error) {
+	resp := errorResponse(err)
+	if resp.Status >= http.StatusInternalServerError {
+		log.Errorf("Request to %s failed: %v", r.URL.Path, err)
+	}
+	sendResponse(w, r, resp)
+}
+
+func (h *WalletHandlers) sendError(w http.ResponseWriter, r *http.Request, err error) {
+	sendError(w, r, h.logger(r.Context()), err)
+}
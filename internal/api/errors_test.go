@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorResponse(t *testing.T) {
+	walletUUID := uuid.New()
+
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		message string
+	}{
+		{"wallet not found", model.NewWalletNotFoundError(walletUUID), http.StatusNotFound, fmt.Sprintf(model.StatusWalletNotFound, walletUUID)},
+		{"wrapped wallet not found", fmt.Errorf("process: %w", model.NewWalletNotFoundError(walletUUID)), http.StatusNotFound, fmt.Sprintf(model.StatusWalletNotFound, walletUUID)},
+		{"bare wallet not found", model.ErrWalletNotFound, http.StatusNotFound, model.StatusWalletNotFoundGeneric},
+		{"insufficient funds", fmt.Errorf("withdraw: %w", model.ErrInsufficientFunds), http.StatusUnprocessableEntity, model.StatusInsufficientFunds},
+		{"currency mismatch", model.ErrCurrencyMismatch, http.StatusUnprocessableEntity, model.StatusCurrencyMismatch},
+		{"exchange rate not found", model.ErrExchangeRateNotFound, http.StatusUnprocessableEntity, model.StatusExchangeRateNotFound},
+		{"invalid exchange", model.ErrInvalidExchange, http.StatusUnprocessableEntity, model.StatusInvalidExchange},
+		{"invalid amount", model.ErrInvalidAmount, http.StatusUnprocessableEntity, model.StatusInvalidAmount},
+		{"hold not found", model.ErrHoldNotFound, http.StatusNotFound, model.StatusHoldNotFound},
+		{"hold not active", model.ErrHoldNotActive, http.StatusConflict, model.StatusHoldNotActive},
+		{"capture exceeds hold", model.ErrCaptureExceedsHold, http.StatusUnprocessableEntity, model.StatusCaptureExceedsHold},
+		{"wallet frozen", model.ErrWalletFrozen, http.StatusLocked, model.StatusWalletFrozen},
+		{"wallet closed", model.ErrWalletClosed, http.StatusGone, model.StatusWalletClosed},
+		{"wallet not empty", model.ErrWalletNotEmpty, http.StatusUnprocessableEntity, model.StatusWalletNotEmpty},
+		{"invalid status change", model.ErrInvalidStatusChange, http.StatusConflict, model.StatusInvalidStatusChange},
+		{"limit exceeded", &model.LimitExceededError{Limit: model.LimitMaxOperation}, http.StatusUnprocessableEntity, fmt.Sprintf(model.StatusLimitExceeded, model.LimitMaxOperation)},
+		{"import rejected", &model.WalletImportError{Errors: []model.ImportLineError{{Line: 2}, {Line: 5}}}, http.StatusUnprocessableEntity, fmt.Sprintf(model.StatusImportRejected, 2)},
+		{"invalid limits", model.ErrInvalidLimits, http.StatusBadRequest, model.StatusInvalidLimits},
+		{"balance before creation", model.ErrBalanceBeforeCreation, http.StatusUnprocessableEntity, model.StatusBalanceBeforeCreation},
+		{"transaction not found", model.ErrTransactionNotFound, http.StatusNotFound, model.StatusTransactionNotFound},
+		{"not reversible", model.ErrNotReversible, http.StatusUnprocessableEntity, model.StatusNotReversible},
+		{"already reversed", model.ErrAlreadyReversed, http.StatusConflict, model.StatusAlreadyReversed},
+		{"reversal exceeds amount", model.ErrReversalExceedsAmount, http.StatusUnprocessableEntity, model.StatusReversalExceedsAmount},
+		{"wallet already exists", model.ErrWalletAlreadyExists, http.StatusConflict, model.StatusWalletAlreadyExists},
+		{"idempotency conflict", model.ErrIdempotencyKeyConflict, http.StatusConflict, model.StatusIdempotencyKeyConflict},
+		{"generic conflict", fmt.Errorf("state changed: %w", model.ErrConflict), http.StatusConflict, model.StatusConflict},
+		{"client canceled", fmt.Errorf("query: %w", context.Canceled), StatusClientClosedRequest, model.StatusRequestCanceled},
+		{"deadline exceeded", fmt.Errorf("query: %w", context.DeadlineExceeded), http.StatusServiceUnavailable, model.StatusRequestTimeout},
+		{"unknown", errors.New("no rows in result set"), http.StatusInternalServerError, model.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := errorResponse(tt.err)
+
+			assert.Equal(t, tt.status, resp.Status)
+			assert.Equal(t, tt.message, resp.Message)
+		})
+	}
+}
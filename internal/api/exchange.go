@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type ExchangeService interface {
+	ExchangeFunds(ctx context.Context, exchange model.Exchange) (model.ExchangeResult, error)
+}
+
+type ExchangeHandlers struct {
+	ExchangeService
+	log *logrus.Logger
+}
+
+func NewExchangeHandler(exchangeService ExchangeService, log *logrus.Logger) ExchangeHandlers {
+	return ExchangeHandlers{ExchangeService: exchangeService, log: log}
+}
+
+func (h *ExchangeHandlers) Register(r *mux.Router) {
+	r.HandleFunc("/api/v1/exchanges", h.Exchange).Methods("POST")
+}
+
+func (h *ExchangeHandlers) sendError(w http.ResponseWriter, r *http.Request, err error) {
+	sendError(w, r, logger.FromContext(r.Context(), h.log), err)
+}
+
+func (h *ExchangeHandlers) Exchange(w http.ResponseWriter, r *http.Request) {
+	var request model.Exchange
+
+	if err := decodeBody(r, &request); err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestBody,
+		})
+		return
+	}
+
+	if !request.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestData,
+		})
+		return
+	}
+
+	result, err := h.ExchangeFunds(r.Context(), request)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusExchangeSuccess,
+		Data:    result,
+	})
+}
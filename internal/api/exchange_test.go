@@ -0,0 +1,92 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchange_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExchangeService := mock.NewMockExchangeService(ctrl)
+
+	request := model.Exchange{
+		FromWalletID: uuid.New(),
+		ToWalletID:   uuid.New(),
+		Amount:       decimal.NewFromInt32(100),
+	}
+	result := model.ExchangeResult{
+		ExchangeID:     uuid.New(),
+		DebitAmount:    request.Amount,
+		DebitCurrency:  "USD",
+		CreditAmount:   decimal.NewFromInt32(7920),
+		CreditCurrency: "RUB",
+		Rate:           decimal.NewFromInt32(80),
+		Spread:         decimal.RequireFromString("0.01"),
+	}
+
+	mockExchangeService.EXPECT().ExchangeFunds(gomock.Any(), request).Return(result, nil)
+
+	handler := api.NewExchangeHandler(mockExchangeService, logger.Discard())
+
+	reqBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/exchanges", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.Exchange(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusExchangeSuccess, resp.Message)
+
+	data := resp.Data.(map[string]interface{})
+	assert.Equal(t, result.ExchangeID.String(), data["exchangeId"])
+	assert.Equal(t, "7920", data["creditAmount"])
+	assert.Equal(t, "RUB", data["creditCurrency"])
+}
+
+func TestExchange_RateNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExchangeService := mock.NewMockExchangeService(ctrl)
+
+	request := model.Exchange{
+		FromWalletID: uuid.New(),
+		ToWalletID:   uuid.New(),
+		Amount:       decimal.NewFromInt32(100),
+	}
+
+	mockExchangeService.EXPECT().ExchangeFunds(gomock.Any(), request).Return(model.ExchangeResult{}, model.ErrExchangeRateNotFound)
+
+	handler := api.NewExchangeHandler(mockExchangeService, logger.Discard())
+
+	reqBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/exchanges", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.Exchange(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusExchangeRateNotFound, resp.Message)
+}
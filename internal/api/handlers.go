@@ -3,41 +3,68 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"log"
+	"errors"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
+	"github.com/dannamer/JavaCode-test/internal/logger"
 	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
 )
 
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 type WalletService interface {
-	WalletTransaction(ctx context.Context, transaction model.Transaction) error
+	WalletTransaction(ctx context.Context, transaction model.Transaction) (model.TransactionRecord, error)
 	GetWalletBalance(ctx context.Context, UUID uuid.UUID) (model.Wallet, error)
+	OpenWallet(ctx context.Context, creation model.WalletCreation) (model.Wallet, error)
+	GetWalletTransactions(ctx context.Context, filter model.TransactionFilter) (model.TransactionList, error)
+	TransferFunds(ctx context.Context, transfer model.Transfer) (model.TransferResult, error)
+	ReverseTransaction(ctx context.Context, reversal model.Reversal) (model.TransactionRecord, error)
+	WalletBatch(ctx context.Context, request model.BatchRequest) (model.BatchResult, error)
+	WalletBalanceAt(ctx context.Context, UUID uuid.UUID, at time.Time) (model.HistoricalBalance, error)
+	CheckWalletBalance(ctx context.Context, UUID uuid.UUID) (model.BalanceCheck, error)
 }
 
 type WalletHandlers struct {
 	WalletService
+	log *logrus.Logger
 }
 
-func NewWalletHandler(WalletService WalletService) WalletHandlers {
-	return WalletHandlers{WalletService: WalletService}
+func NewWalletHandler(WalletService WalletService, log *logrus.Logger) WalletHandlers {
+	return WalletHandlers{WalletService: WalletService, log: log}
 }
 
-func sendResponse(w http.ResponseWriter, r *http.Request, resp model.Response) {
-	log.Printf("Handled request to %s, Status: %d, Message: %s", r.URL.Path, resp.Status, resp.Message)
+func (h *WalletHandlers) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, h.log)
+}
 
+func sendResponse(w http.ResponseWriter, r *http.Request, resp model.Response) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(resp.Status)
 
 	json.NewEncoder(w).Encode(resp)
 }
 
+// decodeBody выделяет разбор тела запроса в отдельный спан.
+func decodeBody(r *http.Request, v any) error {
+	_, span := tracing.Tracer().Start(r.Context(), "decode request")
+	err := json.NewDecoder(r.Body).Decode(v)
+	tracing.End(span, err)
+	return err
+}
+
 func (h *WalletHandlers) WalletOperation(w http.ResponseWriter, r *http.Request) {
 	var response model.Transaction
 
-	if err := json.NewDecoder(r.Body).Decode(&response); err != nil {
+	if err := decodeBody(r, &response); err != nil {
 		sendResponse(w, r, model.Response{
 			Status:  http.StatusBadRequest,
 			Message: model.StatusInvalidRequestBody,
@@ -45,6 +72,8 @@ func (h *WalletHandlers) WalletOperation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	response.IdempotencyKey = r.Header.Get(IdempotencyKeyHeader)
+
 	if !response.Validate() {
 		sendResponse(w, r, model.Response{
 			Status:  http.StatusBadRequest,
@@ -53,32 +82,90 @@ func (h *WalletHandlers) WalletOperation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := h.WalletTransaction(context.Background(), response)
+	record, err := h.WalletTransaction(r.Context(), response)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusTransactionSuccess,
+		Data:    record,
+	})
+}
+
+func (h *WalletHandlers) ReverseTransaction(w http.ResponseWriter, r *http.Request) {
+	transactionUUID, err := uuid.Parse(mux.Vars(r)["TRANSACTION_UUID"])
 	if err != nil {
-		if err.Error() == "insufficient funds" {
-			sendResponse(w, r, model.Response{
-				Status:  http.StatusUnprocessableEntity,
-				Message: model.StatusInsufficientFunds,
-			})
-			return
-		}
-		if err.Error() == "no rows in result set" {
-			sendResponse(w, r, model.Response{
-				Status:  http.StatusNotFound,
-				Message: fmt.Sprintf(model.StatusWalletNotFound, response.WalletID),
-			})
-			return
-		}
 		sendResponse(w, r, model.Response{
-			Status:  http.StatusInternalServerError,
-			Message: model.StatusInternalServerError,
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidTransactionUUID,
 		})
 		return
 	}
 
+	// Пустое тело означает полную отмену.
+	reversal := model.Reversal{TransactionID: transactionUUID}
+	if err := decodeBody(r, &reversal); err != nil && !errors.Is(err, io.EOF) {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestBody,
+		})
+		return
+	}
+	reversal.IdempotencyKey = r.Header.Get(IdempotencyKeyHeader)
+
+	if !reversal.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestData,
+		})
+		return
+	}
+
+	record, err := h.WalletService.ReverseTransaction(r.Context(), reversal)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
 	sendResponse(w, r, model.Response{
 		Status:  http.StatusOK,
-		Message: model.StatusTransactionSuccess,
+		Message: model.StatusReversalSuccess,
+		Data:    record,
+	})
+}
+
+func (h *WalletHandlers) Transfer(w http.ResponseWriter, r *http.Request) {
+	var request model.Transfer
+
+	if err := decodeBody(r, &request); err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestBody,
+		})
+		return
+	}
+
+	if !request.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestData,
+		})
+		return
+	}
+
+	result, err := h.TransferFunds(r.Context(), request)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusTransferSuccess,
+		Data:    result,
 	})
 }
 
@@ -94,19 +181,9 @@ func (h *WalletHandlers) Wallet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	wallet, err := h.GetWalletBalance(context.Background(), walletUUID)
+	wallet, err := h.GetWalletBalance(r.Context(), walletUUID)
 	if err != nil {
-		if err.Error() == "no rows in result set" {
-			sendResponse(w, r, model.Response{
-				Status:  http.StatusNotFound,
-				Message: fmt.Sprintf(model.StatusWalletNotFound, walletUUID),
-			})
-			return
-		}
-		sendResponse(w, r, model.Response{
-			Status:  http.StatusInternalServerError,
-			Message: model.StatusInternalServerError,
-		})
+		h.sendError(w, r, err)
 		return
 	}
 	sendResponse(w, r, model.Response{
@@ -115,3 +192,196 @@ func (h *WalletHandlers) Wallet(w http.ResponseWriter, r *http.Request) {
 		Data:    wallet,
 	})
 }
+
+func (h *WalletHandlers) CreateWallet(w http.ResponseWriter, r *http.Request) {
+	var request model.WalletCreation
+
+	if err := decodeBody(r, &request); err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestBody,
+		})
+		return
+	}
+
+	if !request.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestData,
+		})
+		return
+	}
+
+	wallet, err := h.OpenWallet(r.Context(), request)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusCreated,
+		Message: model.StatusWalletCreated,
+		Data:    wallet,
+	})
+}
+
+// WalletBalance возвращает баланс на момент ?at=<RFC3339>; без параметра — на текущий момент.
+func (h *WalletHandlers) WalletBalance(w http.ResponseWriter, r *http.Request) {
+	walletUUID, err := uuid.Parse(mux.Vars(r)["WALLET_UUID"])
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidUUIDFormat,
+		})
+		return
+	}
+
+	at, err := parseTimeParam(r.URL.Query(), "at")
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidQueryParameters,
+		})
+		return
+	}
+	if at == nil {
+		now := time.Now().UTC()
+		at = &now
+	}
+
+	balance, err := h.WalletBalanceAt(r.Context(), walletUUID, *at)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusHistoricalBalance,
+		Data:    balance,
+	})
+}
+
+// LedgerCheck сверяет баланс кошелька с его проводками в главной книге; расхождение
+// не считается ошибкой запроса и видно по полям balance и ledgerBalance.
+func (h *WalletHandlers) LedgerCheck(w http.ResponseWriter, r *http.Request) {
+	walletUUID, err := uuid.Parse(mux.Vars(r)["WALLET_UUID"])
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidUUIDFormat,
+		})
+		return
+	}
+
+	check, err := h.CheckWalletBalance(r.Context(), walletUUID)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusLedgerCheck,
+		Data:    check,
+	})
+}
+
+func (h *WalletHandlers) WalletTransactions(w http.ResponseWriter, r *http.Request) {
+	walletUUID, err := uuid.Parse(mux.Vars(r)["WALLET_UUID"])
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidUUIDFormat,
+		})
+		return
+	}
+
+	filter, err := parseTransactionFilter(r.URL.Query())
+	filter.WalletID = walletUUID
+	if err != nil || !filter.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidQueryParameters,
+		})
+		return
+	}
+
+	list, err := h.GetWalletTransactions(r.Context(), filter)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusTransactionsSuccess,
+		Data:    list,
+	})
+}
+
+func parseTransactionFilter(query url.Values) (model.TransactionFilter, error) {
+	filter := model.TransactionFilter{
+		OperationType: model.OperationType(query.Get("operationType")),
+		Order:         model.SortDesc,
+		Limit:         model.DefaultTransactionsLimit,
+	}
+
+	if order := query.Get("order"); order != "" {
+		filter.Order = model.SortOrder(order)
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return model.TransactionFilter{}, err
+		}
+		filter.Limit = value
+	}
+	if cursor := query.Get("cursor"); cursor != "" {
+		value, err := model.ParseTransactionCursor(cursor)
+		if err != nil {
+			return model.TransactionFilter{}, err
+		}
+		filter.Cursor = &value
+	}
+
+	var err error
+	if filter.MinAmount, err = parseDecimalParam(query, "minAmount"); err != nil {
+		return model.TransactionFilter{}, err
+	}
+	if filter.MaxAmount, err = parseDecimalParam(query, "maxAmount"); err != nil {
+		return model.TransactionFilter{}, err
+	}
+	if filter.From, err = parseTimeParam(query, "from"); err != nil {
+		return model.TransactionFilter{}, err
+	}
+	if filter.To, err = parseTimeParam(query, "to"); err != nil {
+		return model.TransactionFilter{}, err
+	}
+	return filter, nil
+}
+
+func parseDecimalParam(query url.Values, name string) (*decimal.Decimal, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := decimal.NewFromString(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	value = value.UTC()
+	return &value, nil
+}
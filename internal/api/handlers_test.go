@@ -2,18 +2,22 @@ package api_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/dannamer/JavaCode-test/internal/api"
 	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/logger"
 	"github.com/dannamer/JavaCode-test/internal/model"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
@@ -30,9 +34,18 @@ func TestWalletOperation_Success(t *testing.T) {
 		Amount:        decimal.NewFromInt32(100),
 	}
 
-	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(nil)
+	balanceAfter := decimal.NewFromInt32(250)
+	record := model.TransactionRecord{
+		UUID:          uuid.New(),
+		WalletID:      transaction.WalletID,
+		OperationType: transaction.OperationType,
+		Amount:        transaction.Amount,
+		BalanceAfter:  &balanceAfter,
+	}
+
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(record, nil)
 
-	handler := api.NewWalletHandler(mockWalletService)
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
 
 	reqBody, _ := json.Marshal(transaction)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody))
@@ -46,6 +59,11 @@ func TestWalletOperation_Success(t *testing.T) {
 	err := json.NewDecoder(rr.Body).Decode(&resp)
 	assert.NoError(t, err)
 	assert.Equal(t, model.StatusTransactionSuccess, resp.Message)
+
+	data := resp.Data.(map[string]interface{})
+	assert.Equal(t, record.UUID.String(), data["uuid"])
+	assert.Equal(t, string(model.Deposit), data["operationType"])
+	assert.Equal(t, "250", data["balanceAfter"])
 }
 
 func TestWalletOperation_InsufficientFunds(t *testing.T) {
@@ -60,9 +78,9 @@ func TestWalletOperation_InsufficientFunds(t *testing.T) {
 		Amount:        decimal.NewFromInt32(1000),
 	}
 
-	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(fmt.Errorf("insufficient funds"))
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(model.TransactionRecord{}, model.ErrInsufficientFunds)
 
-	handler := api.NewWalletHandler(mockWalletService)
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
 
 	reqBody, _ := json.Marshal(transaction)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody))
@@ -90,9 +108,9 @@ func TestWalletOperation_WalletNotFound(t *testing.T) {
 		Amount:        decimal.NewFromInt32(100),
 	}
 
-	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(fmt.Errorf("no rows in result set"))
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(model.TransactionRecord{}, model.NewWalletNotFoundError(transaction.WalletID))
 
-	handler := api.NewWalletHandler(mockWalletService)
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
 
 	reqBody, _ := json.Marshal(transaction)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody))
@@ -120,9 +138,9 @@ func TestWalletOperation_WalletErrorDatabase(t *testing.T) {
 		Amount:        decimal.NewFromInt32(100),
 	}
 
-	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(fmt.Errorf("error database"))
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(model.TransactionRecord{}, fmt.Errorf("error database"))
 
-	handler := api.NewWalletHandler(mockWalletService)
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
 
 	reqBody, _ := json.Marshal(transaction)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody))
@@ -150,7 +168,7 @@ func TestWalletOperation_WalletErrorValidate(t *testing.T) {
 		Amount:        decimal.NewFromInt32(-100),
 	}
 
-	handler := api.NewWalletHandler(mockWalletService)
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
 
 	reqBody, _ := json.Marshal(transaction)
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody))
@@ -172,7 +190,7 @@ func TestWalletOperation_InvalidRequestBody(t *testing.T) {
 
 	mockWalletService := mock.NewMockWalletService(ctrl)
 
-	handler := api.NewWalletHandler(mockWalletService)
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
 
 	invalidJSON := `{"walletID": "123e4567-e89b-12d3-a456-426614174000", "amount": 100,` // Некорректный JSON
 
@@ -188,3 +206,683 @@ func TestWalletOperation_InvalidRequestBody(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, model.StatusInvalidRequestBody, resp.Message)
 }
+
+func TestCreateWallet_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	ownerID := "customer-42"
+	creation := model.WalletCreation{
+		OwnerID: &ownerID,
+		Balance: decimal.NewFromInt32(100),
+	}
+	wallet := model.Wallet{
+		UUID:    uuid.New(),
+		OwnerID: &ownerID,
+		Balance: decimal.NewFromInt32(100),
+	}
+
+	mockWalletService.EXPECT().OpenWallet(gomock.Any(), gomock.Any()).Return(wallet, nil)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(creation)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallets", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.CreateWallet(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusWalletCreated, resp.Message)
+	assert.Equal(t, wallet.UUID.String(), resp.Data.(map[string]interface{})["uuid"])
+}
+
+func TestCreateWallet_AlreadyExists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	creation := model.WalletCreation{UUID: uuid.New()}
+
+	mockWalletService.EXPECT().OpenWallet(gomock.Any(), gomock.Any()).Return(model.Wallet{}, model.ErrWalletAlreadyExists)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(creation)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallets", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.CreateWallet(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusWalletAlreadyExists, resp.Message)
+}
+
+func TestCreateWallet_NegativeBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	creation := model.WalletCreation{Balance: decimal.NewFromInt32(-1)}
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(creation)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallets", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.CreateWallet(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusInvalidRequestData, resp.Message)
+}
+
+func TestWalletOperation_IdempotencyKeyPassed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	transaction := model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(100),
+	}
+	expected := transaction
+	expected.IdempotencyKey = "payment-123"
+
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), expected).Return(model.TransactionRecord{}, nil)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transaction)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody))
+	req.Header.Set(api.IdempotencyKeyHeader, "payment-123")
+	rr := httptest.NewRecorder()
+
+	handler.WalletOperation(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestWalletOperation_IdempotencyKeyConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	transaction := model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(100),
+	}
+
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), gomock.Any()).Return(model.TransactionRecord{}, model.ErrIdempotencyKeyConflict)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transaction)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody))
+	req.Header.Set(api.IdempotencyKeyHeader, "payment-123")
+	rr := httptest.NewRecorder()
+
+	handler.WalletOperation(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusIdempotencyKeyConflict, resp.Message)
+}
+
+func TestWalletTransactions_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	walletUUID := uuid.New()
+	minAmount := decimal.NewFromInt32(10)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	expectedFilter := model.TransactionFilter{
+		WalletID:      walletUUID,
+		OperationType: model.Withdraw,
+		MinAmount:     &minAmount,
+		From:          &from,
+		Order:         model.SortAsc,
+		Limit:         20,
+	}
+	list := model.TransactionList{
+		Transactions: []model.TransactionRecord{
+			{UUID: uuid.New(), WalletID: walletUUID, OperationType: model.Withdraw, Amount: decimal.NewFromInt32(15)},
+		},
+	}
+
+	mockWalletService.EXPECT().GetWalletTransactions(gomock.Any(), expectedFilter).Return(list, nil)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	target := fmt.Sprintf("/api/v1/wallets/%s/transactions?operationType=WITHDRAW&minAmount=10&from=2026-03-01T03:00:00%%2B03:00&order=asc&limit=20", walletUUID)
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.WalletTransactions(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusTransactionsSuccess, resp.Message)
+}
+
+func TestWalletTransactions_ExchangeAndReversalOperationTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	walletUUID := uuid.New()
+	for _, operation := range []model.OperationType{model.ExchangeOut, model.ExchangeIn, model.ReversalOut, model.ReversalIn} {
+		mockWalletService.EXPECT().GetWalletTransactions(gomock.Any(), model.TransactionFilter{
+			WalletID:      walletUUID,
+			OperationType: operation,
+			Order:         model.SortDesc,
+			Limit:         model.DefaultTransactionsLimit,
+		}).Return(model.TransactionList{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+walletUUID.String()+"/transactions?operationType="+string(operation), nil)
+		req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+		rr := httptest.NewRecorder()
+
+		handler.WalletTransactions(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code, operation)
+	}
+}
+
+func TestWalletTransactions_InvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	walletUUID := uuid.New()
+	for _, query := range []string{"limit=0", "limit=1000", "order=sideways", "operationType=REFUND", "minAmount=5&maxAmount=1", "from=yesterday", "cursor=bm90LWEtY3Vyc29y"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+walletUUID.String()+"/transactions?"+query, nil)
+		req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+		rr := httptest.NewRecorder()
+
+		handler.WalletTransactions(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+}
+
+func TestWalletBalance_AtTimestamp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	walletUUID := uuid.New()
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	expected := model.HistoricalBalance{WalletID: walletUUID, At: at, Balance: decimal.NewFromInt32(120), Currency: "RUB"}
+
+	mockWalletService.EXPECT().WalletBalanceAt(gomock.Any(), walletUUID, at).Return(expected, nil)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	target := fmt.Sprintf("/api/v1/wallets/%s/balance?at=2026-03-01T03:00:00%%2B03:00", walletUUID)
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.WalletBalance(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusHistoricalBalance, resp.Message)
+}
+
+func TestWalletBalance_DefaultsToNow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	walletUUID := uuid.New()
+
+	mockWalletService.EXPECT().WalletBalanceAt(gomock.Any(), walletUUID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, at time.Time) (model.HistoricalBalance, error) {
+			assert.WithinDuration(t, time.Now(), at, time.Second)
+			return model.HistoricalBalance{WalletID: walletUUID, At: at}, nil
+		})
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+walletUUID.String()+"/balance", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.WalletBalance(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestWalletBalance_InvalidTimestamp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	walletUUID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+walletUUID.String()+"/balance?at=yesterday", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.WalletBalance(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestWalletBalance_BeforeCreation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	walletUUID := uuid.New()
+
+	mockWalletService.EXPECT().WalletBalanceAt(gomock.Any(), walletUUID, gomock.Any()).
+		Return(model.HistoricalBalance{}, model.ErrBalanceBeforeCreation)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+walletUUID.String()+"/balance?at=2020-01-01T00:00:00Z", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.WalletBalance(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestLedgerCheck_Mismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	walletUUID := uuid.New()
+	check := model.BalanceCheck{WalletID: walletUUID, Balance: decimal.NewFromInt32(100), LedgerBalance: decimal.NewFromInt32(90)}
+
+	mockWalletService.EXPECT().CheckWalletBalance(gomock.Any(), walletUUID).Return(check, nil)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/wallets/"+walletUUID.String()+"/ledger-check", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.LedgerCheck(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusLedgerCheck, resp.Message)
+	assert.Equal(t, "90", resp.Data.(map[string]interface{})["ledgerBalance"])
+}
+
+func TestLedgerCheck_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	walletUUID := uuid.New()
+
+	mockWalletService.EXPECT().CheckWalletBalance(gomock.Any(), walletUUID).
+		Return(model.BalanceCheck{}, model.NewWalletNotFoundError(walletUUID))
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/wallets/"+walletUUID.String()+"/ledger-check", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.LedgerCheck(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestTransfer_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	transfer := model.Transfer{
+		FromWalletID: uuid.New(),
+		ToWalletID:   uuid.New(),
+		Amount:       decimal.NewFromInt32(25),
+	}
+	result := model.TransferResult{
+		TransferID:        uuid.New(),
+		FromTransactionID: uuid.New(),
+		ToTransactionID:   uuid.New(),
+	}
+
+	mockWalletService.EXPECT().TransferFunds(gomock.Any(), transfer).Return(result, nil)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transfer)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transfers", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.Transfer(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusTransferSuccess, resp.Message)
+	assert.Equal(t, result.TransferID.String(), resp.Data.(map[string]interface{})["transferId"])
+}
+
+func TestTransfer_SameWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	walletUUID := uuid.New()
+	transfer := model.Transfer{
+		FromWalletID: walletUUID,
+		ToWalletID:   walletUUID,
+		Amount:       decimal.NewFromInt32(25),
+	}
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transfer)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transfers", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.Transfer(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestTransfer_InsufficientFunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	transfer := model.Transfer{
+		FromWalletID: uuid.New(),
+		ToWalletID:   uuid.New(),
+		Amount:       decimal.NewFromInt32(25),
+	}
+
+	mockWalletService.EXPECT().TransferFunds(gomock.Any(), transfer).Return(model.TransferResult{}, model.ErrInsufficientFunds)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transfer)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transfers", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.Transfer(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+}
+
+func TestTransfer_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	transfer := model.Transfer{
+		FromWalletID: uuid.New(),
+		ToWalletID:   uuid.New(),
+		Amount:       decimal.NewFromInt32(25),
+	}
+
+	mockWalletService.EXPECT().TransferFunds(gomock.Any(), transfer).Return(model.TransferResult{}, model.NewWalletNotFoundError(transfer.ToWalletID))
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transfer)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transfers", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.Transfer(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(model.StatusWalletNotFound, transfer.ToWalletID), resp.Message)
+}
+
+type contextKey string
+
+func TestWalletOperation_PropagatesRequestContext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	transaction := model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(100),
+	}
+
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).DoAndReturn(
+		func(ctx context.Context, _ model.Transaction) (model.TransactionRecord, error) {
+			assert.Equal(t, "marker", ctx.Value(contextKey("marker")))
+			return model.TransactionRecord{}, nil
+		})
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transaction)
+	ctx := context.WithValue(context.Background(), contextKey("marker"), "marker")
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody)).WithContext(ctx)
+	rr := httptest.NewRecorder()
+
+	handler.WalletOperation(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestWalletOperation_ClientCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	transaction := model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(100),
+	}
+
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).DoAndReturn(
+		func(ctx context.Context, _ model.Transaction) (model.TransactionRecord, error) {
+			return model.TransactionRecord{}, ctx.Err()
+		})
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transaction)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody)).WithContext(ctx)
+	rr := httptest.NewRecorder()
+
+	handler.WalletOperation(rr, req)
+
+	assert.Equal(t, api.StatusClientClosedRequest, rr.Code)
+}
+
+func TestWalletOperation_AmountExceedsCurrencyPrecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	for _, body := range []string{
+		`{"walletId":"` + uuid.NewString() + `","operationType":"DEPOSIT","amount":"10.005","currency":"USD"}`,
+		`{"walletId":"` + uuid.NewString() + `","operationType":"DEPOSIT","amount":"10","currency":"XXX"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		handler.WalletOperation(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+	}
+}
+
+func TestWalletOperation_CurrencyMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	transaction := model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.RequireFromString("10.25"),
+		Currency:      "USD",
+	}
+
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(model.TransactionRecord{}, model.ErrCurrencyMismatch)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transaction)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.WalletOperation(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusCurrencyMismatch, resp.Message)
+}
+
+func TestWalletOperation_LimitExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+
+	transaction := model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(1000),
+	}
+
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), transaction).Return(model.TransactionRecord{}, &model.LimitExceededError{
+		Limit:     model.LimitMaxOperation,
+		Threshold: decimal.NewFromInt32(500),
+		Attempted: transaction.Amount,
+	})
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	reqBody, _ := json.Marshal(transaction)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.WalletOperation(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	data := resp.Data.(map[string]interface{})
+	assert.Equal(t, string(model.LimitMaxOperation), data["limit"])
+	assert.Equal(t, "500", data["threshold"])
+	assert.Equal(t, "1000", data["attempted"])
+}
+
+func TestWalletOperation_AmountAboveMaxAmount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	mockWalletService.EXPECT().WalletTransaction(gomock.Any(), gomock.Any()).Times(0)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	body := `{"walletId":"` + uuid.NewString() + `","operationType":"DEPOSIT","amount":"1000000000000000000000"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+
+	handler.WalletOperation(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestReverseTransaction_AlreadyReversed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWalletService := mock.NewMockWalletService(ctrl)
+	transactionUUID := uuid.New()
+
+	mockWalletService.EXPECT().ReverseTransaction(gomock.Any(), model.Reversal{TransactionID: transactionUUID}).
+		Return(model.TransactionRecord{}, model.ErrAlreadyReversed)
+
+	handler := api.NewWalletHandler(mockWalletService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/transactions/"+transactionUUID.String()+"/reverse", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Router().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusAlreadyReversed, resp.Message)
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/gorilla/mux"
+)
+
+type HealthService interface {
+	Readiness(ctx context.Context) model.Readiness
+}
+
+type HealthHandlers struct {
+	HealthService
+	shuttingDown atomic.Bool
+}
+
+func NewHealthHandler(healthService HealthService) *HealthHandlers {
+	return &HealthHandlers{HealthService: healthService}
+}
+
+func (h *HealthHandlers) Register(r *mux.Router) {
+	r.HandleFunc("/healthz", h.Liveness).Methods("GET")
+	r.HandleFunc("/readyz", h.ReadinessProbe).Methods("GET")
+}
+
+// MarkShuttingDown переводит /readyz в 503, чтобы балансировщик перестал слать трафик.
+func (h *HealthHandlers) MarkShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+func (h *HealthHandlers) Liveness(w http.ResponseWriter, r *http.Request) {
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusAlive,
+	})
+}
+
+func (h *HealthHandlers) ReadinessProbe(w http.ResponseWriter, r *http.Request) {
+	if h.shuttingDown.Load() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusServiceUnavailable,
+			Message: model.StatusShuttingDown,
+		})
+		return
+	}
+
+	readiness := h.Readiness(r.Context())
+	if !readiness.Ready {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusServiceUnavailable,
+			Message: model.StatusNotReady,
+			Data:    readiness,
+		})
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusReady,
+		Data:    readiness,
+	})
+}
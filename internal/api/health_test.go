@@ -0,0 +1,87 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := api.NewHealthHandler(mock.NewMockHealthService(ctrl))
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Liveness(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestReadinessProbe_Ready(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHealthService := mock.NewMockHealthService(ctrl)
+	mockHealthService.EXPECT().Readiness(gomock.Any()).Return(model.Readiness{Ready: true, MigrationVersion: 6})
+
+	handler := api.NewHealthHandler(mockHealthService)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ReadinessProbe(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusReady, resp.Message)
+}
+
+func TestReadinessProbe_NotReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHealthService := mock.NewMockHealthService(ctrl)
+	mockHealthService.EXPECT().Readiness(gomock.Any()).Return(model.Readiness{Ready: false})
+
+	handler := api.NewHealthHandler(mockHealthService)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ReadinessProbe(rr, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
+
+func TestReadinessProbe_ShuttingDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := api.NewHealthHandler(mock.NewMockHealthService(ctrl))
+	handler.MarkShuttingDown()
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ReadinessProbe(rr, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusShuttingDown, resp.Message)
+}
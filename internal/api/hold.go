@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type HoldService interface {
+	PlaceHold(ctx context.Context, request model.HoldRequest) (model.Hold, error)
+	GetHold(ctx context.Context, UUID uuid.UUID) (model.Hold, error)
+	Capture(ctx context.Context, UUID uuid.UUID, capture model.HoldCapture) (model.HoldCaptureResult, error)
+	Release(ctx context.Context, UUID uuid.UUID) (model.Hold, error)
+}
+
+type HoldHandlers struct {
+	HoldService
+	log *logrus.Logger
+}
+
+func NewHoldHandler(holdService HoldService, log *logrus.Logger) HoldHandlers {
+	return HoldHandlers{HoldService: holdService, log: log}
+}
+
+func (h *HoldHandlers) Register(r *mux.Router) {
+	r.HandleFunc("/api/v1/holds", h.CreateHold).Methods("POST")
+	r.HandleFunc("/api/v1/holds/{HOLD_UUID}", h.Hold).Methods("GET")
+	r.HandleFunc("/api/v1/holds/{HOLD_UUID}/capture", h.CaptureHold).Methods("POST")
+	r.HandleFunc("/api/v1/holds/{HOLD_UUID}/release", h.ReleaseHold).Methods("POST")
+}
+
+func (h *HoldHandlers) sendError(w http.ResponseWriter, r *http.Request, err error) {
+	sendError(w, r, logger.FromContext(r.Context(), h.log), err)
+}
+
+func (h *HoldHandlers) CreateHold(w http.ResponseWriter, r *http.Request) {
+	var request model.HoldRequest
+
+	if err := decodeBody(r, &request); err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestBody,
+		})
+		return
+	}
+
+	if !request.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestData,
+		})
+		return
+	}
+
+	hold, err := h.PlaceHold(r.Context(), request)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusCreated,
+		Message: model.StatusHoldCreated,
+		Data:    hold,
+	})
+}
+
+func (h *HoldHandlers) Hold(w http.ResponseWriter, r *http.Request) {
+	holdUUID, ok := parseHoldUUID(w, r)
+	if !ok {
+		return
+	}
+
+	hold, err := h.GetHold(r.Context(), holdUUID)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusHoldSuccess,
+		Data:    hold,
+	})
+}
+
+func (h *HoldHandlers) CaptureHold(w http.ResponseWriter, r *http.Request) {
+	holdUUID, ok := parseHoldUUID(w, r)
+	if !ok {
+		return
+	}
+
+	// Пустое тело означает списание всего холда.
+	var capture model.HoldCapture
+	if err := decodeBody(r, &capture); err != nil && !errors.Is(err, io.EOF) {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestBody,
+		})
+		return
+	}
+
+	if !capture.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestData,
+		})
+		return
+	}
+
+	result, err := h.Capture(r.Context(), holdUUID, capture)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusHoldCaptured,
+		Data:    result,
+	})
+}
+
+func (h *HoldHandlers) ReleaseHold(w http.ResponseWriter, r *http.Request) {
+	holdUUID, ok := parseHoldUUID(w, r)
+	if !ok {
+		return
+	}
+
+	hold, err := h.Release(r.Context(), holdUUID)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusHoldReleased,
+		Data:    hold,
+	})
+}
+
+func parseHoldUUID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	holdUUID, err := uuid.Parse(mux.Vars(r)["HOLD_UUID"])
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidHoldUUIDFormat,
+		})
+		return uuid.Nil, false
+	}
+	return holdUUID, true
+}
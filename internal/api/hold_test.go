@@ -0,0 +1,111 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateHold_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := mock.NewMockHoldService(ctrl)
+
+	request := model.HoldRequest{WalletID: uuid.New(), Amount: decimal.NewFromInt32(100), TTLSeconds: 60}
+	hold := model.Hold{UUID: uuid.New(), WalletID: request.WalletID, Amount: request.Amount, Currency: "RUB", Status: model.HoldActive}
+
+	mockHoldService.EXPECT().PlaceHold(gomock.Any(), request).Return(hold, nil)
+
+	handler := api.NewHoldHandler(mockHoldService, logger.Discard())
+
+	reqBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/holds", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.CreateHold(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusHoldCreated, resp.Message)
+	assert.Equal(t, hold.UUID.String(), resp.Data.(map[string]interface{})["uuid"])
+}
+
+func TestCreateHold_TTLTooLong(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := mock.NewMockHoldService(ctrl)
+	mockHoldService.EXPECT().PlaceHold(gomock.Any(), gomock.Any()).Times(0)
+
+	handler := api.NewHoldHandler(mockHoldService, logger.Discard())
+
+	request := model.HoldRequest{WalletID: uuid.New(), Amount: decimal.NewFromInt32(100), TTLSeconds: int64(model.MaxHoldTTL.Seconds()) + 1}
+	reqBody, _ := json.Marshal(request)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/holds", bytes.NewBuffer(reqBody))
+	rr := httptest.NewRecorder()
+
+	handler.CreateHold(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestCaptureHold_EmptyBodyCapturesWholeHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := mock.NewMockHoldService(ctrl)
+	holdUUID := uuid.New()
+
+	mockHoldService.EXPECT().Capture(gomock.Any(), holdUUID, model.HoldCapture{}).Return(model.HoldCaptureResult{}, nil)
+
+	handler := api.NewHoldHandler(mockHoldService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/holds/"+holdUUID.String()+"/capture", nil)
+	req = mux.SetURLVars(req, map[string]string{"HOLD_UUID": holdUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.CaptureHold(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestCaptureHold_NotActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHoldService := mock.NewMockHoldService(ctrl)
+	holdUUID := uuid.New()
+
+	mockHoldService.EXPECT().Capture(gomock.Any(), holdUUID, gomock.Any()).Return(model.HoldCaptureResult{}, model.ErrHoldNotActive)
+
+	handler := api.NewHoldHandler(mockHoldService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/holds/"+holdUUID.String()+"/capture", bytes.NewBufferString(`{"amount":"10"}`))
+	req = mux.SetURLVars(req, map[string]string{"HOLD_UUID": holdUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.CaptureHold(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusHoldNotActive, resp.Message)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type LimitsService interface {
+	WalletLimits(ctx context.Context, UUID uuid.UUID) (model.WalletLimits, error)
+	ChangeWalletLimits(ctx context.Context, UUID uuid.UUID, limits model.Limits) (model.WalletLimits, error)
+}
+
+type LimitsHandlers struct {
+	LimitsService
+	log *logrus.Logger
+}
+
+func NewLimitsHandler(limitsService LimitsService, log *logrus.Logger) LimitsHandlers {
+	return LimitsHandlers{LimitsService: limitsService, log: log}
+}
+
+func (h *LimitsHandlers) Register(r *mux.Router) {
+	r.HandleFunc("/api/v1/admin/wallets/{WALLET_UUID}/limits", h.Limits).Methods("GET")
+	r.HandleFunc("/api/v1/admin/wallets/{WALLET_UUID}/limits", h.ChangeLimits).Methods("PUT")
+}
+
+func (h *LimitsHandlers) sendError(w http.ResponseWriter, r *http.Request, err error) {
+	sendError(w, r, logger.FromContext(r.Context(), h.log), err)
+}
+
+func (h *LimitsHandlers) Limits(w http.ResponseWriter, r *http.Request) {
+	walletUUID, err := uuid.Parse(mux.Vars(r)["WALLET_UUID"])
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidUUIDFormat,
+		})
+		return
+	}
+
+	limits, err := h.WalletLimits(r.Context(), walletUUID)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusWalletLimitsSuccess,
+		Data:    limits,
+	})
+}
+
+// ChangeLimits заменяет собственные лимиты кошелька; поле null возвращает лимит по умолчанию.
+func (h *LimitsHandlers) ChangeLimits(w http.ResponseWriter, r *http.Request) {
+	walletUUID, err := uuid.Parse(mux.Vars(r)["WALLET_UUID"])
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidUUIDFormat,
+		})
+		return
+	}
+
+	var limits model.Limits
+	if err := decodeBody(r, &limits); err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestBody,
+		})
+		return
+	}
+
+	if !limits.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestData,
+		})
+		return
+	}
+
+	result, err := h.ChangeWalletLimits(r.Context(), walletUUID, limits)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusWalletLimitsUpdated,
+		Data:    result,
+	})
+}
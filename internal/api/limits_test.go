@@ -0,0 +1,163 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimits_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLimitsService := mock.NewMockLimitsService(ctrl)
+	walletUUID := uuid.New()
+	maxBalance := decimal.NewFromInt32(1000)
+
+	mockLimitsService.EXPECT().WalletLimits(gomock.Any(), walletUUID).
+		Return(model.WalletLimits{WalletID: walletUUID, Effective: model.Limits{MaxBalance: &maxBalance}}, nil)
+
+	handler := api.NewLimitsHandler(mockLimitsService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/wallets/"+walletUUID.String()+"/limits", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.Limits(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusWalletLimitsSuccess, resp.Message)
+}
+
+func TestLimits_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLimitsService := mock.NewMockLimitsService(ctrl)
+	walletUUID := uuid.New()
+
+	mockLimitsService.EXPECT().WalletLimits(gomock.Any(), walletUUID).
+		Return(model.WalletLimits{}, model.NewWalletNotFoundError(walletUUID))
+
+	handler := api.NewLimitsHandler(mockLimitsService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/wallets/"+walletUUID.String()+"/limits", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.Limits(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestChangeLimits_NullFieldsResetToDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLimitsService := mock.NewMockLimitsService(ctrl)
+	walletUUID := uuid.New()
+	maxOperation := decimal.NewFromInt32(50)
+
+	mockLimitsService.EXPECT().ChangeWalletLimits(gomock.Any(), walletUUID, model.Limits{MaxOperation: &maxOperation}).
+		Return(model.WalletLimits{WalletID: walletUUID, Own: model.Limits{MaxOperation: &maxOperation}}, nil)
+
+	handler := api.NewLimitsHandler(mockLimitsService, logger.Discard())
+
+	body := `{"maxOperation": "50", "maxBalance": null, "dailyDeposit": null}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/wallets/"+walletUUID.String()+"/limits", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.ChangeLimits(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusWalletLimitsUpdated, resp.Message)
+}
+
+func TestChangeLimits_NegativeLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLimitsService := mock.NewMockLimitsService(ctrl)
+	mockLimitsService.EXPECT().ChangeWalletLimits(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	handler := api.NewLimitsHandler(mockLimitsService, logger.Discard())
+
+	walletUUID := uuid.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/wallets/"+walletUUID.String()+"/limits",
+		strings.NewReader(`{"dailyWithdrawal": "-1"}`))
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.ChangeLimits(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestChangeLimits_WrongPrecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLimitsService := mock.NewMockLimitsService(ctrl)
+	walletUUID := uuid.New()
+
+	mockLimitsService.EXPECT().ChangeWalletLimits(gomock.Any(), walletUUID, gomock.Any()).
+		Return(model.WalletLimits{}, model.ErrInvalidLimits)
+
+	handler := api.NewLimitsHandler(mockLimitsService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/wallets/"+walletUUID.String()+"/limits",
+		strings.NewReader(`{"maxOperation": "10.001"}`))
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.ChangeLimits(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusInvalidLimits, resp.Message)
+}
+
+func TestChangeLimits_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLimitsService := mock.NewMockLimitsService(ctrl)
+	walletUUID := uuid.New()
+
+	mockLimitsService.EXPECT().ChangeWalletLimits(gomock.Any(), walletUUID, gomock.Any()).
+		Return(model.WalletLimits{}, model.NewWalletNotFoundError(walletUUID))
+
+	handler := api.NewLimitsHandler(mockLimitsService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/wallets/"+walletUUID.String()+"/limits", strings.NewReader(`{}`))
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.ChangeLimits(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	maxRequestIDLength = 128
+)
+
+// withRequestTimeout ограничивает время обработки запроса: по истечении дедлайна
+// контекст запроса отменяется вместе со всеми запросами к базе.
+func withRequestTimeout(next http.Handler, timeout time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// withRequestTimeouts применяет RequestTimeout ко всем запросам, кроме потоковых: массовым
+// импорту и экспорту и выпискам даётся BulkTimeout, и на то же время продлеваются дедлайны
+// чтения и записи соединения, иначе большой файл оборвут ReadTimeout и WriteTimeout сервера.
+func withRequestTimeouts(next http.Handler, config ServerConfig) http.Handler {
+	regular := withRequestTimeout(next, config.RequestTimeout)
+	bulk := withRequestTimeout(next, config.BulkTimeout)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !streamingRequest(r) {
+			regular.ServeHTTP(w, r)
+			return
+		}
+
+		deadline := time.Now().Add(config.BulkTimeout)
+		controller := http.NewResponseController(w)
+		if err := controller.SetReadDeadline(deadline); err == nil {
+			controller.SetWriteDeadline(deadline)
+		}
+		bulk.ServeHTTP(w, r)
+	})
+}
+
+func streamingRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, BulkPathPrefix) || strings.HasSuffix(r.URL.Path, StatementPathSuffix)
+}
+
+// RequestID берёт X-Request-ID от клиента или генерирует новый, кладёт его в контекст
+// и возвращает в ответе.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(logger.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// AccessLog пишет по одной структурированной записи на каждый обработанный запрос.
+func AccessLog(log *logrus.Logger) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writer := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			started := time.Now()
+			next.ServeHTTP(writer, r)
+
+			logger.FromContext(r.Context(), log).WithFields(logrus.Fields{
+				"method":      r.Method,
+				"path":        r.URL.Path,
+				"status":      writer.status,
+				"duration_ms": time.Since(started).Milliseconds(),
+			}).Info("Handled request")
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRequestTimeout_SetsDeadline(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, hasDeadline = r.Context().Deadline()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets", nil)
+	withRequestTimeout(next, time.Second).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestWithRequestTimeouts_StreamingPathsGetBulkTimeout(t *testing.T) {
+	var deadline time.Time
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, _ = r.Context().Deadline()
+	})
+	handler := withRequestTimeouts(next, ServerConfig{RequestTimeout: time.Second, BulkTimeout: time.Hour})
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, BulkPathPrefix+"wallets/export", nil))
+	assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, 100*time.Millisecond)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/wallets/42"+StatementPathSuffix, nil))
+	assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, 100*time.Millisecond)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/wallets", nil))
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestRequestID_ReusesClientHeader(t *testing.T) {
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logger.RequestID(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	rr := httptest.NewRecorder()
+	RequestID(next).ServeHTTP(rr, req)
+
+	assert.Equal(t, "req-42", seen)
+	assert.Equal(t, "req-42", rr.Header().Get(RequestIDHeader))
+}
+
+func TestRequestID_GeneratesWhenMissing(t *testing.T) {
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logger.RequestID(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets", nil)
+	rr := httptest.NewRecorder()
+	RequestID(next).ServeHTTP(rr, req)
+
+	assert.NotEmpty(t, seen)
+	assert.Equal(t, seen, rr.Header().Get(RequestIDHeader))
+}
+
+func TestAccessLog_IncludesRequestID(t *testing.T) {
+	var out bytes.Buffer
+	log := logrus.New()
+	log.SetOutput(&out)
+	log.SetFormatter(&logrus.JSONFormatter{})
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	RequestID(AccessLog(log)(next)).ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, "req-42", entry[logger.FieldRequestID])
+	assert.Equal(t, float64(http.StatusTeapot), entry["status"])
+}
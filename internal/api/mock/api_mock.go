@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/dannamer/JavaCode-test/internal/model"
 	gomock "github.com/golang/mock/gomock"
@@ -36,6 +37,21 @@ func (m *MockWalletService) EXPECT() *MockWalletServiceMockRecorder {
 	return m.recorder
 }
 
+// CheckWalletBalance mocks base method.
+func (m *MockWalletService) CheckWalletBalance(ctx context.Context, UUID uuid.UUID) (model.BalanceCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckWalletBalance", ctx, UUID)
+	ret0, _ := ret[0].(model.BalanceCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckWalletBalance indicates an expected call of CheckWalletBalance.
+func (mr *MockWalletServiceMockRecorder) CheckWalletBalance(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckWalletBalance", reflect.TypeOf((*MockWalletService)(nil).CheckWalletBalance), ctx, UUID)
+}
+
 // GetWalletBalance mocks base method.
 func (m *MockWalletService) GetWalletBalance(ctx context.Context, UUID uuid.UUID) (model.Wallet, error) {
 	m.ctrl.T.Helper()
@@ -51,12 +67,103 @@ func (mr *MockWalletServiceMockRecorder) GetWalletBalance(ctx, UUID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletBalance", reflect.TypeOf((*MockWalletService)(nil).GetWalletBalance), ctx, UUID)
 }
 
+// GetWalletTransactions mocks base method.
+func (m *MockWalletService) GetWalletTransactions(ctx context.Context, filter model.TransactionFilter) (model.TransactionList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletTransactions", ctx, filter)
+	ret0, _ := ret[0].(model.TransactionList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletTransactions indicates an expected call of GetWalletTransactions.
+func (mr *MockWalletServiceMockRecorder) GetWalletTransactions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletTransactions", reflect.TypeOf((*MockWalletService)(nil).GetWalletTransactions), ctx, filter)
+}
+
+// OpenWallet mocks base method.
+func (m *MockWalletService) OpenWallet(ctx context.Context, creation model.WalletCreation) (model.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenWallet", ctx, creation)
+	ret0, _ := ret[0].(model.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenWallet indicates an expected call of OpenWallet.
+func (mr *MockWalletServiceMockRecorder) OpenWallet(ctx, creation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenWallet", reflect.TypeOf((*MockWalletService)(nil).OpenWallet), ctx, creation)
+}
+
+// ReverseTransaction mocks base method.
+func (m *MockWalletService) ReverseTransaction(ctx context.Context, reversal model.Reversal) (model.TransactionRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTransaction", ctx, reversal)
+	ret0, _ := ret[0].(model.TransactionRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTransaction indicates an expected call of ReverseTransaction.
+func (mr *MockWalletServiceMockRecorder) ReverseTransaction(ctx, reversal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTransaction", reflect.TypeOf((*MockWalletService)(nil).ReverseTransaction), ctx, reversal)
+}
+
+// TransferFunds mocks base method.
+func (m *MockWalletService) TransferFunds(ctx context.Context, transfer model.Transfer) (model.TransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferFunds", ctx, transfer)
+	ret0, _ := ret[0].(model.TransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferFunds indicates an expected call of TransferFunds.
+func (mr *MockWalletServiceMockRecorder) TransferFunds(ctx, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferFunds", reflect.TypeOf((*MockWalletService)(nil).TransferFunds), ctx, transfer)
+}
+
+// WalletBalanceAt mocks base method.
+func (m *MockWalletService) WalletBalanceAt(ctx context.Context, UUID uuid.UUID, at time.Time) (model.HistoricalBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletBalanceAt", ctx, UUID, at)
+	ret0, _ := ret[0].(model.HistoricalBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletBalanceAt indicates an expected call of WalletBalanceAt.
+func (mr *MockWalletServiceMockRecorder) WalletBalanceAt(ctx, UUID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletBalanceAt", reflect.TypeOf((*MockWalletService)(nil).WalletBalanceAt), ctx, UUID, at)
+}
+
+// WalletBatch mocks base method.
+func (m *MockWalletService) WalletBatch(ctx context.Context, request model.BatchRequest) (model.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletBatch", ctx, request)
+	ret0, _ := ret[0].(model.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletBatch indicates an expected call of WalletBatch.
+func (mr *MockWalletServiceMockRecorder) WalletBatch(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletBatch", reflect.TypeOf((*MockWalletService)(nil).WalletBatch), ctx, request)
+}
+
 // WalletTransaction mocks base method.
-func (m *MockWalletService) WalletTransaction(ctx context.Context, transaction model.Transaction) error {
+func (m *MockWalletService) WalletTransaction(ctx context.Context, transaction model.Transaction) (model.TransactionRecord, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "WalletTransaction", ctx, transaction)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(model.TransactionRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // WalletTransaction indicates an expected call of WalletTransaction.
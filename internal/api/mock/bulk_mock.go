@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bulk.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBulkService is a mock of BulkService interface.
+type MockBulkService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBulkServiceMockRecorder
+}
+
+// MockBulkServiceMockRecorder is the mock recorder for MockBulkService.
+type MockBulkServiceMockRecorder struct {
+	mock *MockBulkService
+}
+
+// NewMockBulkService creates a new mock instance.
+func NewMockBulkService(ctrl *gomock.Controller) *MockBulkService {
+	mock := &MockBulkService{ctrl: ctrl}
+	mock.recorder = &MockBulkServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBulkService) EXPECT() *MockBulkServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockBulkService) Export(ctx context.Context, format model.BulkFormat, w io.Writer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, format, w)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockBulkServiceMockRecorder) Export(ctx, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBulkService)(nil).Export), ctx, format, w)
+}
+
+// Import mocks base method.
+func (m *MockBulkService) Import(ctx context.Context, format model.BulkFormat, body io.Reader) (model.WalletImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, format, body)
+	ret0, _ := ret[0].(model.WalletImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockBulkServiceMockRecorder) Import(ctx, format, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockBulkService)(nil).Import), ctx, format, body)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: exchange.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExchangeService is a mock of ExchangeService interface.
+type MockExchangeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeServiceMockRecorder
+}
+
+// MockExchangeServiceMockRecorder is the mock recorder for MockExchangeService.
+type MockExchangeServiceMockRecorder struct {
+	mock *MockExchangeService
+}
+
+// NewMockExchangeService creates a new mock instance.
+func NewMockExchangeService(ctrl *gomock.Controller) *MockExchangeService {
+	mock := &MockExchangeService{ctrl: ctrl}
+	mock.recorder = &MockExchangeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeService) EXPECT() *MockExchangeServiceMockRecorder {
+	return m.recorder
+}
+
+// ExchangeFunds mocks base method.
+func (m *MockExchangeService) ExchangeFunds(ctx context.Context, exchange model.Exchange) (model.ExchangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeFunds", ctx, exchange)
+	ret0, _ := ret[0].(model.ExchangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeFunds indicates an expected call of ExchangeFunds.
+func (mr *MockExchangeServiceMockRecorder) ExchangeFunds(ctx, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeFunds", reflect.TypeOf((*MockExchangeService)(nil).ExchangeFunds), ctx, exchange)
+}
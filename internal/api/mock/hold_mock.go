@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: hold.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockHoldService is a mock of HoldService interface.
+type MockHoldService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldServiceMockRecorder
+}
+
+// MockHoldServiceMockRecorder is the mock recorder for MockHoldService.
+type MockHoldServiceMockRecorder struct {
+	mock *MockHoldService
+}
+
+// NewMockHoldService creates a new mock instance.
+func NewMockHoldService(ctrl *gomock.Controller) *MockHoldService {
+	mock := &MockHoldService{ctrl: ctrl}
+	mock.recorder = &MockHoldServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldService) EXPECT() *MockHoldServiceMockRecorder {
+	return m.recorder
+}
+
+// Capture mocks base method.
+func (m *MockHoldService) Capture(ctx context.Context, UUID uuid.UUID, capture model.HoldCapture) (model.HoldCaptureResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, UUID, capture)
+	ret0, _ := ret[0].(model.HoldCaptureResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockHoldServiceMockRecorder) Capture(ctx, UUID, capture interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockHoldService)(nil).Capture), ctx, UUID, capture)
+}
+
+// GetHold mocks base method.
+func (m *MockHoldService) GetHold(ctx context.Context, UUID uuid.UUID) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, UUID)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockHoldServiceMockRecorder) GetHold(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockHoldService)(nil).GetHold), ctx, UUID)
+}
+
+// PlaceHold mocks base method.
+func (m *MockHoldService) PlaceHold(ctx context.Context, request model.HoldRequest) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceHold", ctx, request)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceHold indicates an expected call of PlaceHold.
+func (mr *MockHoldServiceMockRecorder) PlaceHold(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceHold", reflect.TypeOf((*MockHoldService)(nil).PlaceHold), ctx, request)
+}
+
+// Release mocks base method.
+func (m *MockHoldService) Release(ctx context.Context, UUID uuid.UUID) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, UUID)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockHoldServiceMockRecorder) Release(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockHoldService)(nil).Release), ctx, UUID)
+}
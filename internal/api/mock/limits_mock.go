@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: limits.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockLimitsService is a mock of LimitsService interface.
+type MockLimitsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitsServiceMockRecorder
+}
+
+// MockLimitsServiceMockRecorder is the mock recorder for MockLimitsService.
+type MockLimitsServiceMockRecorder struct {
+	mock *MockLimitsService
+}
+
+// NewMockLimitsService creates a new mock instance.
+func NewMockLimitsService(ctrl *gomock.Controller) *MockLimitsService {
+	mock := &MockLimitsService{ctrl: ctrl}
+	mock.recorder = &MockLimitsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitsService) EXPECT() *MockLimitsServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangeWalletLimits mocks base method.
+func (m *MockLimitsService) ChangeWalletLimits(ctx context.Context, UUID uuid.UUID, limits model.Limits) (model.WalletLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeWalletLimits", ctx, UUID, limits)
+	ret0, _ := ret[0].(model.WalletLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeWalletLimits indicates an expected call of ChangeWalletLimits.
+func (mr *MockLimitsServiceMockRecorder) ChangeWalletLimits(ctx, UUID, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeWalletLimits", reflect.TypeOf((*MockLimitsService)(nil).ChangeWalletLimits), ctx, UUID, limits)
+}
+
+// WalletLimits mocks base method.
+func (m *MockLimitsService) WalletLimits(ctx context.Context, UUID uuid.UUID) (model.WalletLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletLimits", ctx, UUID)
+	ret0, _ := ret[0].(model.WalletLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletLimits indicates an expected call of WalletLimits.
+func (mr *MockLimitsServiceMockRecorder) WalletLimits(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletLimits", reflect.TypeOf((*MockLimitsService)(nil).WalletLimits), ctx, UUID)
+}
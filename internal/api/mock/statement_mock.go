@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statement.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStatementService is a mock of StatementService interface.
+type MockStatementService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementServiceMockRecorder
+}
+
+// MockStatementServiceMockRecorder is the mock recorder for MockStatementService.
+type MockStatementServiceMockRecorder struct {
+	mock *MockStatementService
+}
+
+// NewMockStatementService creates a new mock instance.
+func NewMockStatementService(ctrl *gomock.Controller) *MockStatementService {
+	mock := &MockStatementService{ctrl: ctrl}
+	mock.recorder = &MockStatementServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementService) EXPECT() *MockStatementServiceMockRecorder {
+	return m.recorder
+}
+
+// WriteStatement mocks base method.
+func (m *MockStatementService) WriteStatement(ctx context.Context, period model.StatementPeriod, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteStatement", ctx, period, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteStatement indicates an expected call of WriteStatement.
+func (mr *MockStatementServiceMockRecorder) WriteStatement(ctx, period, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteStatement", reflect.TypeOf((*MockStatementService)(nil).WriteStatement), ctx, period, w)
+}
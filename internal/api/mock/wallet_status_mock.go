@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: wallet_status.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockWalletStatusService is a mock of WalletStatusService interface.
+type MockWalletStatusService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletStatusServiceMockRecorder
+}
+
+// MockWalletStatusServiceMockRecorder is the mock recorder for MockWalletStatusService.
+type MockWalletStatusServiceMockRecorder struct {
+	mock *MockWalletStatusService
+}
+
+// NewMockWalletStatusService creates a new mock instance.
+func NewMockWalletStatusService(ctrl *gomock.Controller) *MockWalletStatusService {
+	mock := &MockWalletStatusService{ctrl: ctrl}
+	mock.recorder = &MockWalletStatusServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletStatusService) EXPECT() *MockWalletStatusServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangeWalletStatus mocks base method.
+func (m *MockWalletStatusService) ChangeWalletStatus(ctx context.Context, change model.WalletStatusChange) (model.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeWalletStatus", ctx, change)
+	ret0, _ := ret[0].(model.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeWalletStatus indicates an expected call of ChangeWalletStatus.
+func (mr *MockWalletStatusServiceMockRecorder) ChangeWalletStatus(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeWalletStatus", reflect.TypeOf((*MockWalletStatusService)(nil).ChangeWalletStatus), ctx, change)
+}
+
+// WalletStatusHistory mocks base method.
+func (m *MockWalletStatusService) WalletStatusHistory(ctx context.Context, UUID uuid.UUID) ([]model.WalletStatusAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalletStatusHistory", ctx, UUID)
+	ret0, _ := ret[0].([]model.WalletStatusAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalletStatusHistory indicates an expected call of WalletStatusHistory.
+func (mr *MockWalletStatusServiceMockRecorder) WalletStatusHistory(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalletStatusHistory", reflect.TypeOf((*MockWalletStatusService)(nil).WalletStatusHistory), ctx, UUID)
+}
@@ -1,18 +1,64 @@
 package api
 
 import (
-	"log"
+	"context"
+	"errors"
 	"net/http"
+
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 )
 
-func (h *WalletHandlers) RunServer() {
+func (h *WalletHandlers) Router() *mux.Router {
 	r := mux.NewRouter()
+	r.HandleFunc("/api/v1/wallets", h.CreateWallet).Methods("POST")
 	r.HandleFunc("/api/v1/wallets/{WALLET_UUID}", h.Wallet).Methods("GET")
+	r.HandleFunc("/api/v1/wallets/{WALLET_UUID}/balance", h.WalletBalance).Methods("GET")
+	r.HandleFunc("/api/v1/wallets/{WALLET_UUID}/transactions", h.WalletTransactions).Methods("GET")
 	r.HandleFunc("/api/v1/wallet", h.WalletOperation).Methods("POST")
+	r.HandleFunc("/api/v1/wallet/batch", h.WalletBatch).Methods("POST")
+	r.HandleFunc("/api/v1/admin/wallets/{WALLET_UUID}/ledger-check", h.LedgerCheck).Methods("GET")
+	r.HandleFunc("/api/v1/transfers", h.Transfer).Methods("POST")
+	r.HandleFunc("/api/v1/transactions/{TRANSACTION_UUID}/reverse", h.ReverseTransaction).Methods("POST")
+	return r
+}
+
+// RunServer обслуживает запросы до отмены ctx, после чего вызывает onShutdown и даёт
+// текущим запросам завершиться в пределах ShutdownTimeout.
+func RunServer(ctx context.Context, config ServerConfig, handler http.Handler, log *logrus.Logger, onShutdown ...func()) error {
+	server := &http.Server{
+		Addr:         config.Addr,
+		Handler:      withRequestTimeouts(handler, config),
+		ReadTimeout:  config.ReadTimeout,
+		WriteTimeout: config.WriteTimeout,
+		IdleTimeout:  config.IdleTimeout,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Infof("Server is starting on %s", config.Addr)
+		serverErr <- server.ListenAndServe()
+	}()
 
-	log.Println("Server is starting on port 8080...")
-	if err := http.ListenAndServe(":8080", r); err != nil {
-		log.Fatalf("Error starting server: %v", err)
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Info("Shutting down server")
+	for _, hook := range onShutdown {
+		hook()
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-serverErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
 	}
+	log.Info("Server stopped")
+	return nil
 }
@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunServer_GracefulShutdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := api.NewWalletHandler(mock.NewMockWalletService(ctrl), logger.Discard())
+
+	hookCalled := false
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- api.RunServer(ctx, api.ServerConfig{
+			Addr:            "127.0.0.1:0",
+			ReadTimeout:     time.Second,
+			WriteTimeout:    time.Second,
+			IdleTimeout:     time.Second,
+			ShutdownTimeout: time.Second,
+		}, handler.Router(), logger.Discard(), func() { hookCalled = true })
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+		assert.True(t, hookCalled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop after context cancellation")
+	}
+}
+
+func TestNewServerConfig(t *testing.T) {
+	t.Setenv("HTTP_ADDR", ":9090")
+	t.Setenv("HTTP_WRITE_TIMEOUT", "30s")
+
+	config, err := api.NewServerConfig()
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":9090", config.Addr)
+	assert.Equal(t, 30*time.Second, config.WriteTimeout)
+	assert.Equal(t, 5*time.Second, config.ReadTimeout)
+	assert.Equal(t, 10*time.Minute, config.BulkTimeout)
+}
+
+func TestNewServerConfig_InvalidDuration(t *testing.T) {
+	t.Setenv("HTTP_SHUTDOWN_TIMEOUT", "soon")
+
+	_, err := api.NewServerConfig()
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/statement"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+// StatementPathSuffix — окончание маршрута выписки; для него, как и для массовых
+// операций, действует BulkTimeout.
+const StatementPathSuffix = "/statement"
+
+type StatementService interface {
+	WriteStatement(ctx context.Context, period model.StatementPeriod, w io.Writer) error
+}
+
+type StatementHandlers struct {
+	StatementService
+	log *logrus.Logger
+}
+
+func NewStatementHandler(statementService StatementService, log *logrus.Logger) StatementHandlers {
+	return StatementHandlers{StatementService: statementService, log: log}
+}
+
+func (h *StatementHandlers) Register(r *mux.Router) {
+	r.HandleFunc("/api/v1/wallets/{WALLET_UUID}"+StatementPathSuffix, h.Statement).Methods("GET")
+}
+
+// Statement отдаёт выписку за [from, to) в формате json, csv или pdf. По умолчанию
+// to — текущий момент, а from отстоит от него на DefaultStatementPeriod.
+func (h *StatementHandlers) Statement(w http.ResponseWriter, r *http.Request) {
+	walletUUID, err := uuid.Parse(mux.Vars(r)["WALLET_UUID"])
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidUUIDFormat,
+		})
+		return
+	}
+
+	period, err := parseStatementPeriod(r)
+	period.WalletID = walletUUID
+	if err != nil || !period.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidQueryParameters,
+		})
+		return
+	}
+
+	contentType, extension := statement.ContentType(period.Format)
+	filename := fmt.Sprintf("statement-%s-%s-%s.%s", walletUUID,
+		period.From.Format("20060102"), period.To.Format("20060102"), extension)
+	streamResponse(w, r, logger.FromContext(r.Context(), h.log), contentType, filename, func(out io.Writer) error {
+		return h.WriteStatement(r.Context(), period, out)
+	})
+}
+
+func parseStatementPeriod(r *http.Request) (model.StatementPeriod, error) {
+	query := r.URL.Query()
+	period := model.StatementPeriod{
+		To:     time.Now().UTC(),
+		Format: model.StatementJSON,
+	}
+	if format := query.Get("format"); format != "" {
+		period.Format = model.StatementFormat(format)
+	}
+
+	to, err := parseTimeParam(query, "to")
+	if err != nil {
+		return model.StatementPeriod{}, err
+	}
+	if to != nil {
+		period.To = *to
+	}
+	period.From = period.To.Add(-model.DefaultStatementPeriod)
+
+	from, err := parseTimeParam(query, "from")
+	if err != nil {
+		return model.StatementPeriod{}, err
+	}
+	if from != nil {
+		period.From = *from
+	}
+	return period, nil
+}
@@ -0,0 +1,122 @@
+package api_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatement_PDF(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementService := mock.NewMockStatementService(ctrl)
+	walletUUID := uuid.New()
+	period := model.StatementPeriod{
+		WalletID: walletUUID,
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Format:   model.StatementPDF,
+	}
+	mockStatementService.EXPECT().WriteStatement(gomock.Any(), period, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ model.StatementPeriod, w io.Writer) error {
+			_, err := io.WriteString(w, "%PDF-1.4\n")
+			return err
+		})
+
+	handler := api.NewStatementHandler(mockStatementService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+walletUUID.String()+
+		"/statement?from=2024-01-01T00:00:00Z&to=2024-02-01T00:00:00Z&format=pdf", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.Statement(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "statement-"+walletUUID.String()+"-20240101-20240201.pdf")
+}
+
+func TestStatement_DefaultPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementService := mock.NewMockStatementService(ctrl)
+	walletUUID := uuid.New()
+	mockStatementService.EXPECT().WriteStatement(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, period model.StatementPeriod, _ io.Writer) error {
+			assert.Equal(t, model.StatementJSON, period.Format)
+			assert.WithinDuration(t, time.Now(), period.To, time.Second)
+			assert.Equal(t, model.DefaultStatementPeriod, period.To.Sub(period.From))
+			return nil
+		})
+
+	handler := api.NewStatementHandler(mockStatementService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+walletUUID.String()+"/statement", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.Statement(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestStatement_InvalidPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementService := mock.NewMockStatementService(ctrl)
+	mockStatementService.EXPECT().WriteStatement(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	handler := api.NewStatementHandler(mockStatementService, logger.Discard())
+
+	walletUUID := uuid.New()
+	for _, query := range []string{
+		"?from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+		"?from=yesterday",
+		"?format=xlsx",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+walletUUID.String()+"/statement"+query, nil)
+		req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+		rr := httptest.NewRecorder()
+
+		handler.Statement(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+}
+
+func TestStatement_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatementService := mock.NewMockStatementService(ctrl)
+	walletUUID := uuid.New()
+	mockStatementService.EXPECT().WriteStatement(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(model.NewWalletNotFoundError(walletUUID))
+
+	handler := api.NewStatementHandler(mockStatementService, logger.Discard())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+walletUUID.String()+"/statement?format=csv", nil)
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.Statement(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// streamResponse отдаёт файл по мере того, как stream пишет его. Заголовки откладываются
+// до первых данных, чтобы ошибку, случившуюся до начала выгрузки, вернуть обычным
+// JSON-ответом. После начала выгрузки ошибка обрывает соединение: иначе клиент примет
+// усечённый файл за полный.
+func streamResponse(w http.ResponseWriter, r *http.Request, log *logrus.Entry, contentType, filename string, stream func(io.Writer) error) {
+	out := &streamWriter{ResponseWriter: w, contentType: contentType, filename: filename}
+	if err := stream(out); err != nil {
+		if !out.started {
+			sendError(w, r, log, err)
+			return
+		}
+		log.Errorf("Response stream interrupted: %v", err)
+		panic(http.ErrAbortHandler)
+	}
+	if !out.started {
+		out.start()
+	}
+}
+
+type streamWriter struct {
+	http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *streamWriter) start() {
+	w.started = true
+	w.Header().Set("Content-Type", w.contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+w.filename+`"`)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.start()
+	}
+	return w.ResponseWriter.Write(p)
+}
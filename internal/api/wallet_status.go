@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+)
+
+type WalletStatusService interface {
+	ChangeWalletStatus(ctx context.Context, change model.WalletStatusChange) (model.Wallet, error)
+	WalletStatusHistory(ctx context.Context, UUID uuid.UUID) ([]model.WalletStatusAudit, error)
+}
+
+// WalletStatusHandlers — административные маршруты; доступ к /api/v1/admin ограничивается
+// на уровне шлюза, как и прочие служебные маршруты.
+type WalletStatusHandlers struct {
+	WalletStatusService
+	log *logrus.Logger
+}
+
+func NewWalletStatusHandler(statusService WalletStatusService, log *logrus.Logger) WalletStatusHandlers {
+	return WalletStatusHandlers{WalletStatusService: statusService, log: log}
+}
+
+func (h *WalletStatusHandlers) Register(r *mux.Router) {
+	r.HandleFunc("/api/v1/admin/wallets/{WALLET_UUID}/status", h.ChangeStatus).Methods("PUT")
+	r.HandleFunc("/api/v1/admin/wallets/{WALLET_UUID}/status/history", h.StatusHistory).Methods("GET")
+}
+
+func (h *WalletStatusHandlers) sendError(w http.ResponseWriter, r *http.Request, err error) {
+	sendError(w, r, logger.FromContext(r.Context(), h.log), err)
+}
+
+func (h *WalletStatusHandlers) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	walletUUID, err := uuid.Parse(mux.Vars(r)["WALLET_UUID"])
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidUUIDFormat,
+		})
+		return
+	}
+
+	var change model.WalletStatusChange
+	if err := decodeBody(r, &change); err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestBody,
+		})
+		return
+	}
+	change.WalletID = walletUUID
+
+	if !change.Validate() {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidRequestData,
+		})
+		return
+	}
+
+	wallet, err := h.ChangeWalletStatus(r.Context(), change)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusWalletStatusChanged,
+		Data:    wallet,
+	})
+}
+
+func (h *WalletStatusHandlers) StatusHistory(w http.ResponseWriter, r *http.Request) {
+	walletUUID, err := uuid.Parse(mux.Vars(r)["WALLET_UUID"])
+	if err != nil {
+		sendResponse(w, r, model.Response{
+			Status:  http.StatusBadRequest,
+			Message: model.StatusInvalidUUIDFormat,
+		})
+		return
+	}
+
+	history, err := h.WalletStatusHistory(r.Context(), walletUUID)
+	if err != nil {
+		h.sendError(w, r, err)
+		return
+	}
+
+	sendResponse(w, r, model.Response{
+		Status:  http.StatusOK,
+		Message: model.StatusWalletStatusHistory,
+		Data:    history,
+	})
+}
@@ -0,0 +1,72 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/api"
+	"github.com/dannamer/JavaCode-test/internal/api/mock"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeStatus_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatusService := mock.NewMockWalletStatusService(ctrl)
+	walletUUID := uuid.New()
+	change := model.WalletStatusChange{
+		WalletID: walletUUID,
+		Status:   model.WalletFrozen,
+		Reason:   "suspicious activity",
+		Actor:    "compliance@example.com",
+	}
+
+	mockStatusService.EXPECT().ChangeWalletStatus(gomock.Any(), change).
+		Return(model.Wallet{UUID: walletUUID, Status: model.WalletFrozen}, nil)
+
+	handler := api.NewWalletStatusHandler(mockStatusService, logger.Discard())
+
+	reqBody, _ := json.Marshal(change)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/wallets/"+walletUUID.String()+"/status", bytes.NewBuffer(reqBody))
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.ChangeStatus(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var resp model.Response
+	err := json.NewDecoder(rr.Body).Decode(&resp)
+	assert.NoError(t, err)
+	assert.Equal(t, model.StatusWalletStatusChanged, resp.Message)
+	assert.Equal(t, string(model.WalletFrozen), resp.Data.(map[string]interface{})["status"])
+}
+
+func TestChangeStatus_ReasonRequired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStatusService := mock.NewMockWalletStatusService(ctrl)
+	mockStatusService.EXPECT().ChangeWalletStatus(gomock.Any(), gomock.Any()).Times(0)
+
+	handler := api.NewWalletStatusHandler(mockStatusService, logger.Discard())
+
+	walletUUID := uuid.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/wallets/"+walletUUID.String()+"/status",
+		bytes.NewBufferString(`{"status":"CLOSED","actor":"compliance@example.com"}`))
+	req = mux.SetURLVars(req, map[string]string{"WALLET_UUID": walletUUID.String()})
+	rr := httptest.NewRecorder()
+
+	handler.ChangeStatus(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
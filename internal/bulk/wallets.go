@@ -0,0 +1,221 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// maxNDJSONLine — предел длины строки NDJSON; строка кошелька укладывается в него с большим запасом.
+const maxNDJSONLine = 64 * 1024
+
+// lineError — ошибка одной строки потока; чтение после неё продолжается.
+type lineError struct {
+	line    int64
+	message string
+}
+
+func (e *lineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.line, e.message)
+}
+
+type readFunc func() (int64, model.WalletCreation, error)
+
+// WalletReader разбирает поток кошельков для импорта и реализует model.WalletImportSource.
+// Кошельки без UUID получают новый, начальный баланс проверяется по лимитам по умолчанию
+// для валюты кошелька. После первой некорректной строки он перестаёт отдавать строки, но
+// дочитывает поток, чтобы сообщить обо всех ошибках сразу.
+type WalletReader struct {
+	read      readFunc
+	limits    model.CurrencyLimits
+	row       model.WalletImportRow
+	seen      map[uuid.UUID]int64
+	errors    []model.ImportLineError
+	truncated bool
+	err       error
+}
+
+func NewWalletReader(format model.BulkFormat, r io.Reader, limits model.CurrencyLimits) *WalletReader {
+	reader := &WalletReader{limits: limits, seen: make(map[uuid.UUID]int64)}
+	if format == model.BulkNDJSON {
+		reader.read = readNDJSON(r)
+	} else {
+		reader.read = readCSV(r)
+	}
+	return reader
+}
+
+func (r *WalletReader) Next() bool {
+	for r.err == nil && !r.truncated {
+		line, wallet, err := r.read()
+		var invalid *lineError
+		switch {
+		case errors.Is(err, io.EOF):
+			return false
+		case errors.As(err, &invalid):
+			r.reject(invalid.line, invalid.message)
+			continue
+		case err != nil:
+			r.err = err
+			return false
+		}
+
+		if message := r.validate(line, &wallet); message != "" {
+			r.reject(line, message)
+			continue
+		}
+		if len(r.errors) > 0 {
+			continue
+		}
+		r.row = model.WalletImportRow{Line: line, Wallet: wallet}
+		return true
+	}
+	return false
+}
+
+func (r *WalletReader) Row() model.WalletImportRow {
+	return r.row
+}
+
+func (r *WalletReader) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.errors) > 0 {
+		return &model.WalletImportError{Errors: r.errors, Truncated: r.truncated}
+	}
+	return nil
+}
+
+func (r *WalletReader) reject(line int64, message string) {
+	if len(r.errors) == model.MaxImportErrors {
+		r.truncated = true
+		return
+	}
+	r.errors = append(r.errors, model.ImportLineError{Line: line, Message: message})
+}
+
+func (r *WalletReader) validate(line int64, wallet *model.WalletCreation) string {
+	if !wallet.ValidateOwnerID() {
+		return fmt.Sprintf("ownerId must be 1 to %d characters", model.MaxOwnerIDLength)
+	}
+	if _, ok := model.LookupCurrency(wallet.CurrencyCode()); !ok {
+		return fmt.Sprintf("unknown currency %q", wallet.CurrencyCode())
+	}
+	if !wallet.ValidateBalance() {
+		return fmt.Sprintf("balance %s is not valid for %s", wallet.Balance, wallet.CurrencyCode())
+	}
+	if wallet.Balance.IsPositive() {
+		if err := r.limits.For(wallet.CurrencyCode()).CheckOpening(wallet.Balance); err != nil {
+			return err.Error()
+		}
+	}
+	wallet.Currency = wallet.CurrencyCode()
+
+	if wallet.UUID == uuid.Nil {
+		wallet.UUID = uuid.New()
+		return ""
+	}
+	if first, ok := r.seen[wallet.UUID]; ok {
+		return fmt.Sprintf("duplicate uuid %s, first seen on line %d", wallet.UUID, first)
+	}
+	r.seen[wallet.UUID] = line
+	return ""
+}
+
+// readCSV читает CSV с заголовком: обязательна колонка balance, uuid, owner_id и currency
+// необязательны, остальные колонки (например, status из экспорта) игнорируются.
+func readCSV(r io.Reader) readFunc {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	var columns map[string]int
+
+	return func() (int64, model.WalletCreation, error) {
+		if columns == nil {
+			header, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return 0, model.WalletCreation{}, errors.New("missing CSV header")
+			}
+			if err != nil {
+				return 0, model.WalletCreation{}, fmt.Errorf("invalid CSV header: %w", err)
+			}
+			columns = make(map[string]int, len(header))
+			for i, name := range header {
+				columns[strings.TrimSpace(name)] = i
+			}
+			if _, ok := columns["balance"]; !ok {
+				return 0, model.WalletCreation{}, errors.New("CSV header has no balance column")
+			}
+		}
+
+		record, err := reader.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return int64(parseErr.StartLine), model.WalletCreation{}, &lineError{line: int64(parseErr.StartLine), message: parseErr.Err.Error()}
+		}
+		if err != nil {
+			return 0, model.WalletCreation{}, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		var wallet model.WalletCreation
+		if raw := field("uuid"); raw != "" {
+			if wallet.UUID, err = uuid.Parse(raw); err != nil {
+				return int64(line), wallet, &lineError{line: int64(line), message: fmt.Sprintf("invalid uuid %q", raw)}
+			}
+		}
+		if raw := field("owner_id"); raw != "" {
+			wallet.OwnerID = &raw
+		}
+		if raw := field("balance"); raw != "" {
+			if wallet.Balance, err = decimal.NewFromString(raw); err != nil {
+				return int64(line), wallet, &lineError{line: int64(line), message: fmt.Sprintf("invalid balance %q", raw)}
+			}
+		}
+		wallet.Currency = field("currency")
+		return int64(line), wallet, nil
+	}
+}
+
+// readNDJSON читает по объекту кошелька на строку с полями как в POST /api/v1/wallets;
+// пустые строки пропускаются.
+func readNDJSON(r io.Reader) readFunc {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxNDJSONLine)
+	var line int64
+
+	return func() (int64, model.WalletCreation, error) {
+		for scanner.Scan() {
+			line++
+			raw := strings.TrimSpace(scanner.Text())
+			if raw == "" {
+				continue
+			}
+
+			var wallet model.WalletCreation
+			if err := json.Unmarshal([]byte(raw), &wallet); err != nil {
+				return line, wallet, &lineError{line: line, message: fmt.Sprintf("invalid JSON: %v", err)}
+			}
+			return line, wallet, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return line + 1, model.WalletCreation{}, fmt.Errorf("line %d: %w", line+1, err)
+		}
+		return line, model.WalletCreation{}, io.EOF
+	}
+}
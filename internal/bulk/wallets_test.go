@@ -0,0 +1,134 @@
+package bulk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func readAll(reader *WalletReader) []model.WalletImportRow {
+	var rows []model.WalletImportRow
+	for reader.Next() {
+		rows = append(rows, reader.Row())
+	}
+	return rows
+}
+
+func TestWalletReader_CSV(t *testing.T) {
+	walletUUID := uuid.New()
+	input := "uuid,owner_id,balance,currency,status\n" +
+		walletUUID.String() + ",owner-1,100.50,USD,ACTIVE\n" +
+		",,0,,ACTIVE\n"
+
+	reader := NewWalletReader(model.BulkCSV, strings.NewReader(input), model.CurrencyLimits{})
+	rows := readAll(reader)
+
+	assert.NoError(t, reader.Err())
+	assert.Len(t, rows, 2)
+	assert.Equal(t, int64(2), rows[0].Line)
+	assert.Equal(t, walletUUID, rows[0].Wallet.UUID)
+	assert.Equal(t, "owner-1", *rows[0].Wallet.OwnerID)
+	assert.True(t, decimal.RequireFromString("100.5").Equal(rows[0].Wallet.Balance))
+	assert.Equal(t, int64(3), rows[1].Line)
+	assert.NotEqual(t, uuid.Nil, rows[1].Wallet.UUID)
+	assert.Nil(t, rows[1].Wallet.OwnerID)
+	assert.Equal(t, model.DefaultCurrency, rows[1].Wallet.Currency)
+}
+
+func TestWalletReader_CSVReportsEveryInvalidLine(t *testing.T) {
+	walletUUID := uuid.New().String()
+	input := "uuid,balance,currency\n" +
+		walletUUID + ",10,RUB\n" +
+		"not-a-uuid,10,RUB\n" +
+		",-5,RUB\n" +
+		",10,XXX\n" +
+		walletUUID + ",1.001,RUB\n" +
+		",10,RUB\n"
+
+	reader := NewWalletReader(model.BulkCSV, strings.NewReader(input), model.CurrencyLimits{})
+	rows := readAll(reader)
+
+	// Первая строка корректна, но после ошибок строки больше не отдаются.
+	assert.Len(t, rows, 1)
+
+	var invalid *model.WalletImportError
+	assert.ErrorAs(t, reader.Err(), &invalid)
+	lines := make([]int64, 0, len(invalid.Errors))
+	for _, lineErr := range invalid.Errors {
+		lines = append(lines, lineErr.Line)
+	}
+	assert.Equal(t, []int64{3, 4, 5, 6}, lines)
+	assert.Contains(t, invalid.Errors[2].Message, "unknown currency")
+}
+
+func TestWalletReader_CSVDefaultLimits(t *testing.T) {
+	// Лимиты заданы только для RUB: кошелёк в USD с тем же балансом ими не ограничен.
+	input := "balance,currency\n" + "100,RUB\n" + "5000,RUB\n" + "1500,RUB\n" + "5000,USD\n"
+
+	maxBalance, maxOperation := decimal.NewFromInt32(1000), decimal.NewFromInt32(2000)
+	defaults := model.CurrencyLimits{"RUB": {MaxBalance: &maxBalance, MaxOperation: &maxOperation}}
+	reader := NewWalletReader(model.BulkCSV, strings.NewReader(input), defaults)
+	readAll(reader)
+
+	var invalid *model.WalletImportError
+	assert.ErrorAs(t, reader.Err(), &invalid)
+	assert.Len(t, invalid.Errors, 2)
+	assert.Equal(t, int64(3), invalid.Errors[0].Line)
+	assert.Contains(t, invalid.Errors[0].Message, string(model.LimitMaxOperation))
+	assert.Equal(t, int64(4), invalid.Errors[1].Line)
+	assert.Contains(t, invalid.Errors[1].Message, string(model.LimitMaxBalance))
+}
+
+func TestWalletReader_CSVDuplicateUUID(t *testing.T) {
+	walletUUID := uuid.New().String()
+	input := "uuid,balance\n" + walletUUID + ",1\n" + walletUUID + ",2\n"
+
+	reader := NewWalletReader(model.BulkCSV, strings.NewReader(input), model.CurrencyLimits{})
+	readAll(reader)
+
+	var invalid *model.WalletImportError
+	assert.ErrorAs(t, reader.Err(), &invalid)
+	assert.Equal(t, int64(3), invalid.Errors[0].Line)
+	assert.Contains(t, invalid.Errors[0].Message, "first seen on line 2")
+}
+
+func TestWalletReader_CSVWithoutBalanceColumn(t *testing.T) {
+	reader := NewWalletReader(model.BulkCSV, strings.NewReader("uuid,currency\n"), model.CurrencyLimits{})
+
+	assert.False(t, reader.Next())
+	assert.EqualError(t, reader.Err(), "CSV header has no balance column")
+}
+
+func TestWalletReader_NDJSON(t *testing.T) {
+	input := `{"ownerId":"owner-1","balance":"5","currency":"EUR","status":"ACTIVE"}` + "\n" +
+		"\n" +
+		`{"balance":` + "\n" +
+		`{"balance":"1"}` + "\n"
+
+	reader := NewWalletReader(model.BulkNDJSON, strings.NewReader(input), model.CurrencyLimits{})
+	rows := readAll(reader)
+
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "EUR", rows[0].Wallet.Currency)
+
+	var invalid *model.WalletImportError
+	assert.ErrorAs(t, reader.Err(), &invalid)
+	assert.Len(t, invalid.Errors, 1)
+	assert.Equal(t, int64(3), invalid.Errors[0].Line)
+}
+
+func TestWalletReader_StopsAfterMaxErrors(t *testing.T) {
+	input := strings.Repeat("{\"balance\":\"-1\"}\n", model.MaxImportErrors+10)
+
+	reader := NewWalletReader(model.BulkNDJSON, strings.NewReader(input), model.CurrencyLimits{})
+	readAll(reader)
+
+	var invalid *model.WalletImportError
+	assert.ErrorAs(t, reader.Err(), &invalid)
+	assert.Len(t, invalid.Errors, model.MaxImportErrors)
+	assert.True(t, invalid.Truncated)
+}
@@ -0,0 +1,58 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+)
+
+// StaticRates — неизменяемый набор курсов из конфигурации. Подходит для тестов и
+// автономной работы; курс обратной пары выводится из прямой.
+type StaticRates struct {
+	rates map[string]model.ExchangeRate
+}
+
+func NewStaticRates(rates []model.ExchangeRate) (*StaticRates, error) {
+	static := &StaticRates{rates: make(map[string]model.ExchangeRate, len(rates))}
+	for _, rate := range rates {
+		if !rate.Validate() {
+			return nil, fmt.Errorf("invalid exchange rate %s/%s", rate.Base, rate.Quote)
+		}
+		static.rates[pair(rate.Base, rate.Quote)] = rate
+	}
+	return static, nil
+}
+
+// LoadStaticRates читает JSON-массив курсов из файла. Пустой путь даёт провайдер без курсов.
+func LoadStaticRates(path string) (*StaticRates, error) {
+	if path == "" {
+		return NewStaticRates(nil)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rates []model.ExchangeRate
+	if err = json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return NewStaticRates(rates)
+}
+
+func (s *StaticRates) Rate(_ context.Context, base, quote string) (model.ExchangeRate, error) {
+	if rate, ok := s.rates[pair(base, quote)]; ok {
+		return rate, nil
+	}
+	if rate, ok := s.rates[pair(quote, base)]; ok {
+		return rate.Inverse(), nil
+	}
+	return model.ExchangeRate{}, fmt.Errorf("%s/%s: %w", base, quote, model.ErrExchangeRateNotFound)
+}
+
+func pair(base, quote string) string {
+	return base + "/" + quote
+}
@@ -0,0 +1,39 @@
+package exchange
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRates_Rate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	err := os.WriteFile(path, []byte(`[{"base":"USD","quote":"RUB","rate":"80","spread":"0.01"}]`), 0o600)
+	assert.NoError(t, err)
+
+	rates, err := LoadStaticRates(path)
+	assert.NoError(t, err)
+
+	direct, err := rates.Rate(context.Background(), "USD", "RUB")
+	assert.NoError(t, err)
+	assert.True(t, direct.Rate.Equal(decimal.NewFromInt(80)))
+
+	inverse, err := rates.Rate(context.Background(), "RUB", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, "RUB", inverse.Base)
+	assert.True(t, inverse.Rate.Equal(decimal.RequireFromString("0.0125")))
+	assert.True(t, inverse.Spread.Equal(direct.Spread))
+
+	_, err = rates.Rate(context.Background(), "USD", "EUR")
+	assert.ErrorIs(t, err, model.ErrExchangeRateNotFound)
+}
+
+func TestNewStaticRates_InvalidRate(t *testing.T) {
+	_, err := NewStaticRates([]model.ExchangeRate{{Base: "USD", Quote: "RUB", Rate: decimal.Zero}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+const (
+	FieldRequestID       = "request_id"
+	FieldWalletUUID      = "wallet_uuid"
+	FieldOperation       = "operation"
+	FieldTransactionUUID = "transaction_uuid"
+	FieldTransferUUID    = "transfer_uuid"
+	FieldAmount          = "amount"
+	FieldOutcome         = "outcome"
+)
+
+type Config struct {
+	Level  string
+	Format string
+}
+
+func NewConfig() Config {
+	config := Config{Level: "info", Format: FormatJSON}
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		config.Level = level
+	}
+	if format := os.Getenv("LOG_FORMAT"); format != "" {
+		config.Format = format
+	}
+	return config
+}
+
+func New(config Config) (*logrus.Logger, error) {
+	level, err := logrus.ParseLevel(config.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logrus.New()
+	log.SetLevel(level)
+	log.SetOutput(os.Stdout)
+
+	switch config.Format {
+	case FormatJSON:
+		log.SetFormatter(&logrus.JSONFormatter{})
+	case FormatText:
+		log.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	default:
+		return nil, fmt.Errorf("unknown log format %q", config.Format)
+	}
+
+	return log, nil
+}
+
+// Discard возвращает логгер без вывода — для тестов.
+func Discard() *logrus.Logger {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	return log
+}
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// FromContext добавляет к логгеру request_id из контекста, связывая записи всех слоёв одного запроса.
+func FromContext(ctx context.Context, log *logrus.Logger) *logrus.Entry {
+	entry := logrus.NewEntry(log)
+	if requestID := RequestID(ctx); requestID != "" {
+		entry = entry.WithField(FieldRequestID, requestID)
+	}
+	return entry
+}
@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := New(Config{Level: "loud", Format: FormatJSON})
+	assert.Error(t, err)
+
+	_, err = New(Config{Level: "info", Format: "xml"})
+	assert.Error(t, err)
+}
+
+func TestFromContext_AddsRequestID(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-42")
+
+	entry := FromContext(ctx, Discard())
+
+	assert.Equal(t, "req-42", entry.Data[FieldRequestID])
+}
+
+func TestFromContext_WithoutRequestID(t *testing.T) {
+	entry := FromContext(context.Background(), Discard())
+
+	assert.NotContains(t, entry.Data, FieldRequestID)
+}
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "wallet"
+
+// OperationTransfer и OperationExchange — метки для операций над двумя кошельками,
+// у которых нет собственного OperationType.
+const (
+	OperationTransfer = "TRANSFER"
+	OperationExchange = "EXCHANGE"
+)
+
+const (
+	OutcomeSuccess           = "success"
+	OutcomeInsufficientFunds = "insufficient_funds"
+	OutcomeNotFound          = "not_found"
+	OutcomeConflict          = "conflict"
+	OutcomeCurrencyMismatch  = "currency_mismatch"
+	OutcomeInvalid           = "invalid"
+	OutcomeBlocked           = "wallet_blocked"
+	OutcomeLimitExceeded     = "limit_exceeded"
+	OutcomeError             = "error"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	operations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "operations_total",
+		Help:      "Wallet operations by type and outcome.",
+	}, []string{"operation", "outcome"})
+
+	lockWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "row_lock_wait_seconds",
+		Help:      "Time spent acquiring wallet row locks, including the locking statement itself.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+
+	reconciliationMismatches = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "reconciliation_mismatched_wallets",
+		Help:      "Wallets whose balance disagreed with their history in the last reconciliation run.",
+	})
+)
+
+// Outcome сводит ошибку операции к метке для wallet_operations_total.
+func Outcome(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, model.ErrInsufficientFunds):
+		return OutcomeInsufficientFunds
+	case errors.Is(err, model.ErrWalletNotFound):
+		return OutcomeNotFound
+	case errors.Is(err, model.ErrCurrencyMismatch):
+		return OutcomeCurrencyMismatch
+	case errors.Is(err, model.ErrLimitExceeded):
+		return OutcomeLimitExceeded
+	case errors.Is(err, model.ErrWalletFrozen), errors.Is(err, model.ErrWalletClosed):
+		return OutcomeBlocked
+	case errors.Is(err, model.ErrInvalidExchange), errors.Is(err, model.ErrExchangeRateNotFound),
+		errors.Is(err, model.ErrReversalExceedsAmount):
+		return OutcomeInvalid
+	case errors.Is(err, model.ErrConflict):
+		return OutcomeConflict
+	default:
+		return OutcomeError
+	}
+}
+
+func ObserveOperation(operation string, err error) {
+	operations.WithLabelValues(operation, Outcome(err)).Inc()
+}
+
+func ObserveLockWait(operation string, started time.Time) {
+	lockWait.WithLabelValues(operation).Observe(time.Since(started).Seconds())
+}
+
+func ObserveReconciliation(mismatches int) {
+	reconciliationMismatches.Set(float64(mismatches))
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// InstrumentHandler — middleware для mux.Router: метка route берётся из шаблона пути,
+// чтобы UUID кошельков не раздували число временных рядов.
+func InstrumentHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := "unknown"
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		started := time.Now()
+		next.ServeHTTP(recorder, r)
+
+		status := strconv.Itoa(recorder.status)
+		httpRequests.WithLabelValues(route, r.Method, status).Inc()
+		httpDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(started).Seconds())
+	})
+}
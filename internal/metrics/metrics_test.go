@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutcome(t *testing.T) {
+	assert.Equal(t, OutcomeSuccess, Outcome(nil))
+	assert.Equal(t, OutcomeInsufficientFunds, Outcome(fmt.Errorf("withdraw: %w", model.ErrInsufficientFunds)))
+	assert.Equal(t, OutcomeNotFound, Outcome(model.NewWalletNotFoundError(uuid.New())))
+	assert.Equal(t, OutcomeConflict, Outcome(model.ErrIdempotencyKeyConflict))
+	assert.Equal(t, OutcomeBlocked, Outcome(model.ErrWalletFrozen))
+	assert.Equal(t, OutcomeError, Outcome(errors.New("connection reset")))
+}
+
+func TestObserveOperation(t *testing.T) {
+	before := testutil.ToFloat64(operations.WithLabelValues("WITHDRAW", OutcomeInsufficientFunds))
+
+	ObserveOperation("WITHDRAW", model.ErrInsufficientFunds)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(operations.WithLabelValues("WITHDRAW", OutcomeInsufficientFunds)))
+}
+
+func TestInstrumentHandler_UsesRouteTemplate(t *testing.T) {
+	r := mux.NewRouter()
+	r.Use(InstrumentHandler)
+	r.HandleFunc("/api/v1/wallets/{WALLET_UUID}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}).Methods("GET")
+
+	route := "/api/v1/wallets/{WALLET_UUID}"
+	before := testutil.ToFloat64(httpRequests.WithLabelValues(route, http.MethodGet, "404"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/"+uuid.NewString(), nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, before+1, testutil.ToFloat64(httpRequests.WithLabelValues(route, http.MethodGet, "404")))
+}
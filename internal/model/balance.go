@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// SnapshotInterval — шаг снимков балансов: снимки снимаются на полночь UTC.
+	SnapshotInterval = 24 * time.Hour
+	// SnapshotGrace — задержка снимка после полуночи. Операция, начатая до полуночи, получает
+	// created_at до неё, но может зафиксироваться позже; снимок, снятый сразу, её бы пропустил.
+	SnapshotGrace            = time.Hour
+	DefaultSnapshotBatchSize = 1000
+)
+
+// SnapshotBoundary возвращает последнюю полночь UTC, на которую уже можно снять снимок.
+func SnapshotBoundary(now time.Time) time.Time {
+	return now.UTC().Add(-SnapshotGrace).Truncate(SnapshotInterval)
+}
+
+// HistoricalBalance — баланс кошелька с учётом всех операций с created_at не позже At.
+type HistoricalBalance struct {
+	WalletID uuid.UUID       `json:"walletId"`
+	At       time.Time       `json:"at"`
+	Balance  decimal.Decimal `json:"balance"`
+	Currency string          `json:"currency"`
+	// SnapshotAt — снимок, от которого отсчитан баланс; пусто, если отсчёт шёл от текущего баланса.
+	SnapshotAt *time.Time `json:"snapshotAt,omitempty"`
+}
+
+// BalanceSnapshotBatch — итог снятия снимков для одной пачки кошельков.
+type BalanceSnapshotBatch struct {
+	// LastWalletID — последний UUID пачки; uuid.Nil, если кошельков больше нет.
+	LastWalletID uuid.UUID
+	Created      int64
+}
@@ -0,0 +1,128 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type BatchMode string
+
+const (
+	// BatchAtomic применяет все операции или ни одной.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort применяет выполнимые операции и возвращает ошибки остальных.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+const MaxBatchSize = 1000
+
+// maxBatchKeySuffix — длина суффикса ":<индекс>" в ключе идемпотентности операции пакета.
+var maxBatchKeySuffix = len(strconv.Itoa(MaxBatchSize-1)) + 1
+
+type BatchRequest struct {
+	Mode         BatchMode     `json:"mode"`
+	Transactions []Transaction `json:"transactions"`
+
+	// IdempotencyKey приходит в заголовке Idempotency-Key и относится ко всему пакету.
+	IdempotencyKey string `json:"-"`
+}
+
+// BatchMode возвращает режим пакета; без mode пакет выполняется атомарно.
+func (b *BatchRequest) BatchMode() BatchMode {
+	if b.Mode == "" {
+		return BatchAtomic
+	}
+	return b.Mode
+}
+
+func (b *BatchRequest) Validate() bool {
+	mode := b.BatchMode()
+	return (mode == BatchAtomic || mode == BatchBestEffort) &&
+		len(b.Transactions) > 0 && len(b.Transactions) <= MaxBatchSize &&
+		len(b.IdempotencyKey) <= MaxIdempotencyKeyLength-maxBatchKeySuffix
+}
+
+// ItemIdempotencyKey возвращает ключ операции пакета вида <ключ пакета>:<индекс>; под ним
+// операция сохраняется, и по нему повтор пакета находит уже записанные операции.
+func (b *BatchRequest) ItemIdempotencyKey(index int) string {
+	if b.IdempotencyKey == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", b.IdempotencyKey, index)
+}
+
+// InvalidItem возвращает индекс первой некорректной операции. Некорректная операция
+// отклоняет весь пакет в любом режиме: это ошибка клиента, а не состояния кошелька.
+func (b *BatchRequest) InvalidItem() (int, bool) {
+	for i := range b.Transactions {
+		if !b.Transactions[i].Validate() {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// WalletIDs возвращает кошельки пакета без повторов.
+func (b *BatchRequest) WalletIDs() []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(b.Transactions))
+	var UUIDs []uuid.UUID
+	for _, transaction := range b.Transactions {
+		if !seen[transaction.WalletID] {
+			seen[transaction.WalletID] = true
+			UUIDs = append(UUIDs, transaction.WalletID)
+		}
+	}
+	return UUIDs
+}
+
+type BatchItemStatus string
+
+const (
+	BatchItemSucceeded BatchItemStatus = "SUCCEEDED"
+	BatchItemFailed    BatchItemStatus = "FAILED"
+	// BatchItemRolledBack — выполнимая операция атомарного пакета, отменённая из-за ошибки в другой.
+	BatchItemRolledBack BatchItemStatus = "ROLLED_BACK"
+)
+
+type BatchItemResult struct {
+	Index       int                `json:"index"`
+	Status      BatchItemStatus    `json:"status"`
+	Transaction *TransactionRecord `json:"transaction,omitempty"`
+	// Err переводится в HTTP-статус и сообщение слоем API.
+	Err error `json:"-"`
+}
+
+type BatchResult struct {
+	Mode      BatchMode         `json:"mode"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Items     []BatchItemResult `json:"items"`
+}
+
+// Rejected возвращает первую неуспешную операцию атомарного пакета.
+func (r *BatchResult) Rejected() (BatchItemResult, bool) {
+	if r.Mode != BatchAtomic {
+		return BatchItemResult{}, false
+	}
+	for _, item := range r.Items {
+		if item.Status == BatchItemFailed {
+			return item, true
+		}
+	}
+	return BatchItemResult{}, false
+}
+
+// Tally пересчитывает Succeeded и Failed по статусам операций.
+func (r *BatchResult) Tally() {
+	r.Succeeded, r.Failed = 0, 0
+	for _, item := range r.Items {
+		switch item.Status {
+		case BatchItemSucceeded:
+			r.Succeeded++
+		case BatchItemFailed:
+			r.Failed++
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCurrency — валюта кошельков и операций, созданных без явного указания валюты.
+const DefaultCurrency = "RUB"
+
+// MaxCurrencyPrecision ограничен масштабом денежных колонок в базе (DECIMAL(38, 18)).
+const MaxCurrencyPrecision = 18
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z0-9]{2,10}$`)
+
+type Currency struct {
+	Code string
+	// Precision — число знаков после запятой в сумме.
+	Precision int32
+}
+
+// ValidAmount сообщает, укладывается ли сумма в точность валюты.
+func (c Currency) ValidAmount(amount decimal.Decimal) bool {
+	return amount.Equal(amount.Truncate(c.Precision))
+}
+
+// currencies заполняется при старте процесса и дальше только читается.
+var currencies = map[string]Currency{
+	"AED": {Code: "AED", Precision: 2},
+	"BYN": {Code: "BYN", Precision: 2},
+	"CHF": {Code: "CHF", Precision: 2},
+	"CNY": {Code: "CNY", Precision: 2},
+	"EUR": {Code: "EUR", Precision: 2},
+	"GBP": {Code: "GBP", Precision: 2},
+	"JPY": {Code: "JPY", Precision: 0},
+	"KWD": {Code: "KWD", Precision: 3},
+	"KZT": {Code: "KZT", Precision: 2},
+	"RUB": {Code: "RUB", Precision: 2},
+	"TRY": {Code: "TRY", Precision: 2},
+	"USD": {Code: "USD", Precision: 2},
+}
+
+func LookupCurrency(code string) (Currency, bool) {
+	currency, ok := currencies[code]
+	return currency, ok
+}
+
+// RegisterCurrency добавляет пользовательский актив. Вызывается только при старте,
+// до начала обработки запросов.
+func RegisterCurrency(currency Currency) error {
+	if !currencyCodePattern.MatchString(currency.Code) {
+		return fmt.Errorf("invalid currency code %q", currency.Code)
+	}
+	if currency.Precision < 0 || currency.Precision > MaxCurrencyPrecision {
+		return fmt.Errorf("invalid precision %d for currency %s", currency.Precision, currency.Code)
+	}
+	currencies[currency.Code] = currency
+	return nil
+}
+
+// ParseCurrencies разбирает список активов вида "USDT:6,BTC:8".
+func ParseCurrencies(raw string) ([]Currency, error) {
+	var parsed []Currency
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		code, precision, found := strings.Cut(item, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid currency %q, expected CODE:PRECISION", item)
+		}
+		value, err := strconv.ParseInt(precision, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid precision in currency %q", item)
+		}
+		parsed = append(parsed, Currency{Code: code, Precision: int32(value)})
+	}
+	return parsed, nil
+}
+
+// ValidAmountIn проверяет, что валюта известна, а сумма укладывается в её точность и в MaxAmount.
+func ValidAmountIn(code string, amount decimal.Decimal) bool {
+	currency, ok := LookupCurrency(code)
+	return ok && currency.ValidAmount(amount) && amount.LessThan(MaxAmount)
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWalletNotFound         = errors.New("wallet not found")
+	ErrInsufficientFunds      = errors.New("insufficient funds")
+	ErrConflict               = errors.New("conflict")
+	ErrWalletAlreadyExists    = fmt.Errorf("wallet already exists: %w", ErrConflict)
+	ErrIdempotencyKeyConflict = fmt.Errorf("idempotency key reused with a different payload: %w", ErrConflict)
+	ErrUnbalancedPosting      = errors.New("ledger posting is not balanced")
+	ErrCurrencyMismatch       = errors.New("currency does not match the wallet currency")
+	ErrExchangeRateNotFound   = errors.New("exchange rate not found")
+	ErrInvalidExchange        = errors.New("invalid exchange")
+	ErrHoldNotFound           = errors.New("hold not found")
+	ErrHoldNotActive          = fmt.Errorf("hold is no longer active: %w", ErrConflict)
+	ErrCaptureExceedsHold     = errors.New("capture amount exceeds the held amount")
+	ErrInvalidAmount          = errors.New("amount is not valid for the currency")
+	ErrWalletFrozen           = errors.New("wallet is frozen")
+	ErrWalletClosed           = errors.New("wallet is closed")
+	ErrWalletNotEmpty         = errors.New("wallet has funds or active holds")
+	ErrInvalidStatusChange    = fmt.Errorf("wallet status transition is not allowed: %w", ErrConflict)
+	ErrLimitExceeded          = errors.New("wallet limit exceeded")
+	ErrTransactionNotFound    = errors.New("transaction not found")
+	ErrNotReversible          = errors.New("transaction type cannot be reversed")
+	ErrAlreadyReversed        = fmt.Errorf("transaction is already fully reversed: %w", ErrConflict)
+	ErrReversalExceedsAmount  = errors.New("reversal exceeds the remaining transaction amount")
+	ErrInvalidImport          = errors.New("wallet import has invalid lines")
+	ErrBalanceBeforeCreation  = errors.New("wallet did not exist at the requested time")
+	ErrInvalidLimits          = errors.New("limits are not valid for the wallet currency")
+)
+
+// WalletNotFoundError уточняет ErrWalletNotFound UUID отсутствующего кошелька.
+type WalletNotFoundError struct {
+	UUID uuid.UUID
+}
+
+func NewWalletNotFoundError(UUID uuid.UUID) error {
+	return &WalletNotFoundError{UUID: UUID}
+}
+
+func (e *WalletNotFoundError) Error() string {
+	return fmt.Sprintf("wallet %s not found", e.UUID)
+}
+
+func (e *WalletNotFoundError) Is(target error) bool {
+	return target == ErrWalletNotFound
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// MaxExchangeSpread — спред задаётся долей от суммы и не может съесть её целиком.
+var MaxExchangeSpread = decimal.NewFromInt(1)
+
+// ExchangeRate — сколько единиц Quote дают за единицу Base до вычета спреда.
+type ExchangeRate struct {
+	Base   string          `json:"base"`
+	Quote  string          `json:"quote"`
+	Rate   decimal.Decimal `json:"rate"`
+	Spread decimal.Decimal `json:"spread"`
+}
+
+func (r *ExchangeRate) Validate() bool {
+	return r.Base != "" && r.Quote != "" && r.Base != r.Quote && r.Rate.IsPositive() &&
+		!r.Spread.IsNegative() && r.Spread.LessThan(MaxExchangeSpread)
+}
+
+// Inverse возвращает курс обратной пары с тем же спредом.
+func (r *ExchangeRate) Inverse() ExchangeRate {
+	return ExchangeRate{
+		Base:   r.Quote,
+		Quote:  r.Base,
+		Rate:   decimal.NewFromInt(1).DivRound(r.Rate, MaxCurrencyPrecision),
+		Spread: r.Spread,
+	}
+}
+
+// Convert пересчитывает сумму за вычетом спреда и округляет вниз до точности валюты зачисления,
+// чтобы остаток от округления оставался у сервиса, а не создавался из ничего.
+func (r *ExchangeRate) Convert(amount decimal.Decimal, precision int32) decimal.Decimal {
+	return amount.Mul(r.Rate).Mul(decimal.NewFromInt(1).Sub(r.Spread)).RoundDown(precision)
+}
+
+type Exchange struct {
+	FromWalletID uuid.UUID       `json:"fromWalletId"`
+	ToWalletID   uuid.UUID       `json:"toWalletId"`
+	Amount       decimal.Decimal `json:"amount"`
+}
+
+func (e *Exchange) Validate() bool {
+	return e.FromWalletID != uuid.Nil && e.ToWalletID != uuid.Nil && e.FromWalletID != e.ToWalletID &&
+		e.Amount.GreaterThan(decimal.Zero)
+}
+
+// ExchangeOrder — обмен с зафиксированным курсом, готовый к проведению.
+type ExchangeOrder struct {
+	ID     uuid.UUID
+	Debit  Transaction
+	Credit Transaction
+	Rate   ExchangeRate
+}
+
+// NewExchangeOrder списывает Amount в валюте rate.Base и зачисляет creditAmount в валюте rate.Quote.
+func NewExchangeOrder(exchange Exchange, rate ExchangeRate, creditAmount decimal.Decimal) ExchangeOrder {
+	order := ExchangeOrder{ID: uuid.New(), Rate: rate}
+	order.Debit = Transaction{
+		WalletID:      exchange.FromWalletID,
+		OperationType: ExchangeOut,
+		Amount:        exchange.Amount,
+		Currency:      rate.Base,
+		TransferID:    order.ID,
+		Exchange:      &rate,
+	}
+	order.Credit = Transaction{
+		WalletID:      exchange.ToWalletID,
+		OperationType: ExchangeIn,
+		Amount:        creditAmount,
+		Currency:      rate.Quote,
+		TransferID:    order.ID,
+		Exchange:      &rate,
+	}
+	return order
+}
+
+type ExchangeResult struct {
+	ExchangeID        uuid.UUID       `json:"exchangeId"`
+	FromTransactionID uuid.UUID       `json:"fromTransactionId"`
+	ToTransactionID   uuid.UUID       `json:"toTransactionId"`
+	DebitAmount       decimal.Decimal `json:"debitAmount"`
+	DebitCurrency     string          `json:"debitCurrency"`
+	CreditAmount      decimal.Decimal `json:"creditAmount"`
+	CreditCurrency    string          `json:"creditCurrency"`
+	Rate              decimal.Decimal `json:"rate"`
+	Spread            decimal.Decimal `json:"spread"`
+}
@@ -0,0 +1,21 @@
+package model
+
+const (
+	CheckOK        = "ok"
+	CheckSaturated = "saturated"
+)
+
+type PoolStats struct {
+	AcquiredConns int32   `json:"acquiredConns"`
+	IdleConns     int32   `json:"idleConns"`
+	TotalConns    int32   `json:"totalConns"`
+	MaxConns      int32   `json:"maxConns"`
+	Saturation    float64 `json:"saturation"`
+}
+
+type Readiness struct {
+	Ready            bool              `json:"ready"`
+	MigrationVersion uint              `json:"migrationVersion"`
+	Checks           map[string]string `json:"checks"`
+	Pool             PoolStats         `json:"pool"`
+}
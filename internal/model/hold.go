@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "ACTIVE"
+	HoldCaptured HoldStatus = "CAPTURED"
+	HoldReleased HoldStatus = "RELEASED"
+	HoldExpired  HoldStatus = "EXPIRED"
+)
+
+const (
+	DefaultHoldTTL = 15 * time.Minute
+	MaxHoldTTL     = 7 * 24 * time.Hour
+)
+
+type Hold struct {
+	UUID           uuid.UUID        `json:"uuid"`
+	WalletID       uuid.UUID        `json:"walletId"`
+	Amount         decimal.Decimal  `json:"amount"`
+	CapturedAmount *decimal.Decimal `json:"capturedAmount,omitempty"`
+	Currency       string           `json:"currency"`
+	Status         HoldStatus       `json:"status"`
+	TransactionID  *uuid.UUID       `json:"transactionId,omitempty"`
+	ExpiresAt      time.Time        `json:"expiresAt"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+// HoldRequest резервирует сумму на кошельке; без ttlSeconds холд живёт DefaultHoldTTL.
+type HoldRequest struct {
+	WalletID   uuid.UUID       `json:"walletId"`
+	Amount     decimal.Decimal `json:"amount"`
+	Currency   string          `json:"currency"`
+	TTLSeconds int64           `json:"ttlSeconds"`
+}
+
+func (h *HoldRequest) CurrencyCode() string {
+	if h.Currency == "" {
+		return DefaultCurrency
+	}
+	return h.Currency
+}
+
+func (h *HoldRequest) TTL() time.Duration {
+	if h.TTLSeconds == 0 {
+		return DefaultHoldTTL
+	}
+	return time.Duration(h.TTLSeconds) * time.Second
+}
+
+func (h *HoldRequest) Validate() bool {
+	return h.WalletID != uuid.Nil && h.Amount.GreaterThan(decimal.Zero) &&
+		ValidAmountIn(h.CurrencyCode(), h.Amount) &&
+		h.TTLSeconds >= 0 && h.TTL() <= MaxHoldTTL
+}
+
+// HoldCapture списывает часть холда или, без amount, весь холд. Остаток после
+// частичного списания освобождается: холд списывается только один раз.
+type HoldCapture struct {
+	Amount *decimal.Decimal `json:"amount"`
+}
+
+func (c *HoldCapture) Validate() bool {
+	return c.Amount == nil || c.Amount.GreaterThan(decimal.Zero)
+}
+
+type HoldCaptureResult struct {
+	Hold        Hold              `json:"hold"`
+	Transaction TransactionRecord `json:"transaction"`
+}
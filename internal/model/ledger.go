@@ -0,0 +1,117 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// LedgerAccount — счёт главной книги: кошелёк или системный счёт сервиса.
+type LedgerAccount string
+
+const (
+	// CashAccount — внешняя касса, против которой проводятся пополнения и списания.
+	CashAccount LedgerAccount = "system:cash"
+	// OpeningAccount — входящие остатки кошельков, созданных до появления книги.
+	OpeningAccount LedgerAccount = "system:opening"
+	// ExchangeAccount — валютная позиция сервиса; на ней же остаётся доход от спреда.
+	ExchangeAccount LedgerAccount = "system:exchange"
+)
+
+func WalletAccount(UUID uuid.UUID) LedgerAccount {
+	return LedgerAccount("wallet:" + UUID.String())
+}
+
+type EntrySide string
+
+const (
+	Debit  EntrySide = "DEBIT"
+	Credit EntrySide = "CREDIT"
+)
+
+type LedgerEntry struct {
+	TransactionID *uuid.UUID
+	Account       LedgerAccount
+	Side          EntrySide
+	Amount        decimal.Decimal
+	Currency      string
+}
+
+// Counter возвращает зеркальную проводку на другом счёте.
+func (e LedgerEntry) Counter(account LedgerAccount) LedgerEntry {
+	side := Debit
+	if e.Side == Debit {
+		side = Credit
+	}
+	return LedgerEntry{Account: account, Side: side, Amount: e.Amount, Currency: e.Currency}
+}
+
+// WalletEntry — проводка по счёту кошелька для сохранённой операции. Кошелёк — обязательство
+// сервиса перед владельцем, поэтому зачисления идут по кредиту, а списания — по дебету.
+func WalletEntry(record TransactionRecord) LedgerEntry {
+	side := Debit
+	if record.OperationType.IsCredit() {
+		side = Credit
+	}
+	transactionID := record.UUID
+	return LedgerEntry{
+		TransactionID: &transactionID,
+		Account:       WalletAccount(record.WalletID),
+		Side:          side,
+		Amount:        record.Amount,
+		Currency:      record.Currency,
+	}
+}
+
+// Posting — набор проводок одной операции; в каждой валюте сумма дебетов обязана совпадать с суммой кредитов.
+type Posting struct {
+	ID      uuid.UUID
+	Entries []LedgerEntry
+}
+
+func (p *Posting) Balanced() bool {
+	totals := make(map[string]decimal.Decimal)
+	for _, entry := range p.Entries {
+		if entry.Side == Debit {
+			totals[entry.Currency] = totals[entry.Currency].Add(entry.Amount)
+		} else {
+			totals[entry.Currency] = totals[entry.Currency].Sub(entry.Amount)
+		}
+	}
+	for _, total := range totals {
+		if !total.IsZero() {
+			return false
+		}
+	}
+	return len(p.Entries) >= 2
+}
+
+// CashPosting проводит пополнение или списание кошелька против кассы.
+func CashPosting(record TransactionRecord) Posting {
+	entry := WalletEntry(record)
+	return Posting{ID: record.UUID, Entries: []LedgerEntry{entry, entry.Counter(CashAccount)}}
+}
+
+// TransferPosting объединяет обе стороны перевода в одну проводку.
+func TransferPosting(transferID uuid.UUID, debit, credit TransactionRecord) Posting {
+	return Posting{ID: transferID, Entries: []LedgerEntry{WalletEntry(debit), WalletEntry(credit)}}
+}
+
+// ExchangePosting проводит обе стороны обмена через валютную позицию, чтобы проводка сходилась в каждой валюте.
+func ExchangePosting(exchangeID uuid.UUID, debit, credit TransactionRecord) Posting {
+	debitEntry, creditEntry := WalletEntry(debit), WalletEntry(credit)
+	return Posting{ID: exchangeID, Entries: []LedgerEntry{
+		debitEntry, debitEntry.Counter(ExchangeAccount),
+		creditEntry, creditEntry.Counter(ExchangeAccount),
+	}}
+}
+
+// BalanceCheck сравнивает сохранённый баланс кошелька с балансом, выведенным из проводок.
+type BalanceCheck struct {
+	WalletID      uuid.UUID       `json:"walletId"`
+	Balance       decimal.Decimal `json:"balance"`
+	LedgerBalance decimal.Decimal `json:"ledgerBalance"`
+}
+
+func (c *BalanceCheck) Consistent() bool {
+	return c.Balance.Equal(c.LedgerBalance)
+}
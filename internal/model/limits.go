@@ -0,0 +1,190 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// MaxAmount — абсолютная верхняя граница суммы: DECIMAL(38, 18) вмещает 20 целых разрядов.
+var MaxAmount = decimal.New(1, 18)
+
+// MaxStoredBalance — строгая верхняя граница баланса, которую вмещает wallets.balance.
+var MaxStoredBalance = decimal.New(1, 20)
+
+const (
+	DailyLimitWindow   = 24 * time.Hour
+	MonthlyLimitWindow = 30 * 24 * time.Hour
+)
+
+type LimitKind string
+
+const (
+	LimitMaxOperation      LimitKind = "max_operation"
+	LimitMaxBalance        LimitKind = "max_balance"
+	LimitDailyDeposit      LimitKind = "daily_deposit"
+	LimitDailyWithdrawal   LimitKind = "daily_withdrawal"
+	LimitMonthlyDeposit    LimitKind = "monthly_deposit"
+	LimitMonthlyWithdrawal LimitKind = "monthly_withdrawal"
+	// LimitBalanceCapacity не настраивается: это ёмкость столбца баланса.
+	LimitBalanceCapacity LimitKind = "balance_capacity"
+)
+
+// Limits — лимиты кошелька в его валюте; nil означает отсутствие лимита. Зачисления
+// любого вида считаются пополнениями, списания — выводом.
+type Limits struct {
+	MaxOperation      *decimal.Decimal `json:"maxOperation"`
+	MaxBalance        *decimal.Decimal `json:"maxBalance"`
+	DailyDeposit      *decimal.Decimal `json:"dailyDeposit"`
+	DailyWithdrawal   *decimal.Decimal `json:"dailyWithdrawal"`
+	MonthlyDeposit    *decimal.Decimal `json:"monthlyDeposit"`
+	MonthlyWithdrawal *decimal.Decimal `json:"monthlyWithdrawal"`
+}
+
+func (l Limits) fields() []*decimal.Decimal {
+	return []*decimal.Decimal{l.MaxOperation, l.MaxBalance, l.DailyDeposit, l.DailyWithdrawal, l.MonthlyDeposit, l.MonthlyWithdrawal}
+}
+
+func (l *Limits) Validate() bool {
+	for _, limit := range l.fields() {
+		if limit != nil && (limit.IsNegative() || !limit.LessThan(MaxAmount)) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidIn проверяет, что лимиты укладываются в точность валюты кошелька.
+func (l *Limits) ValidIn(currency string) bool {
+	for _, limit := range l.fields() {
+		if limit != nil && !ValidAmountIn(currency, *limit) {
+			return false
+		}
+	}
+	return true
+}
+
+// Override возвращает лимиты, в которых заданные в override значения заменяют текущие.
+func (l Limits) Override(override Limits) Limits {
+	pick := func(base, over *decimal.Decimal) *decimal.Decimal {
+		if over != nil {
+			return over
+		}
+		return base
+	}
+	return Limits{
+		MaxOperation:      pick(l.MaxOperation, override.MaxOperation),
+		MaxBalance:        pick(l.MaxBalance, override.MaxBalance),
+		DailyDeposit:      pick(l.DailyDeposit, override.DailyDeposit),
+		DailyWithdrawal:   pick(l.DailyWithdrawal, override.DailyWithdrawal),
+		MonthlyDeposit:    pick(l.MonthlyDeposit, override.MonthlyDeposit),
+		MonthlyWithdrawal: pick(l.MonthlyWithdrawal, override.MonthlyWithdrawal),
+	}
+}
+
+// CurrencyLimits — лимиты по умолчанию по коду валюты. Суммы разных валют несравнимы,
+// поэтому для валюты без записи лимитов по умолчанию нет.
+type CurrencyLimits map[string]Limits
+
+// For возвращает лимиты по умолчанию для валюты.
+func (c CurrencyLimits) For(currency string) Limits {
+	return c[currency]
+}
+
+// velocity возвращает суточный и месячный лимиты для направления операции.
+func (l Limits) velocity(credit bool) (daily, monthly *decimal.Decimal) {
+	if credit {
+		return l.DailyDeposit, l.MonthlyDeposit
+	}
+	return l.DailyWithdrawal, l.MonthlyWithdrawal
+}
+
+// VelocityOperationTypes возвращает типы операций, из которых складываются суммы за окна
+// лимитов направления. Отмен среди них нет: отмена уменьшает сумму отменённой операции.
+func VelocityOperationTypes(credit bool) []OperationType {
+	var types []OperationType
+	for _, operation := range OperationTypes {
+		if operation.IsCredit() == credit && operation != ReversalOut && operation != ReversalIn {
+			types = append(types, operation)
+		}
+	}
+	return types
+}
+
+// NeedsTotals сообщает, нужны ли для проверки суммы операций за скользящие окна.
+func (l Limits) NeedsTotals(credit bool) bool {
+	daily, monthly := l.velocity(credit)
+	return daily != nil || monthly != nil
+}
+
+// LimitUsage — операция и её контекст на момент проверки: Balance — баланс после
+// операции, Daily и Monthly — суммы операций того же направления за окна, включая текущую.
+// Reversal отмечает отмену: она возвращает средства операции, уже прошедшей проверку
+// лимитов, поэтому к ней применяется только ёмкость баланса.
+type LimitUsage struct {
+	Credit   bool
+	Reversal bool
+	Amount   decimal.Decimal
+	Balance  decimal.Decimal
+	Daily    decimal.Decimal
+	Monthly  decimal.Decimal
+}
+
+// Check возвращает LimitExceededError для первого нарушенного лимита.
+func (l Limits) Check(usage LimitUsage) error {
+	if usage.Credit && !usage.Balance.LessThan(MaxStoredBalance) {
+		return &LimitExceededError{Limit: LimitBalanceCapacity, Threshold: MaxStoredBalance, Attempted: usage.Balance}
+	}
+	if usage.Reversal {
+		return nil
+	}
+	if l.MaxOperation != nil && usage.Amount.GreaterThan(*l.MaxOperation) {
+		return &LimitExceededError{Limit: LimitMaxOperation, Threshold: *l.MaxOperation, Attempted: usage.Amount}
+	}
+	// Списание с кошелька сверх лимита баланса разрешено: оно уменьшает превышение.
+	if usage.Credit && l.MaxBalance != nil && usage.Balance.GreaterThan(*l.MaxBalance) {
+		return &LimitExceededError{Limit: LimitMaxBalance, Threshold: *l.MaxBalance, Attempted: usage.Balance}
+	}
+
+	daily, monthly := l.velocity(usage.Credit)
+	dailyKind, monthlyKind := LimitDailyWithdrawal, LimitMonthlyWithdrawal
+	if usage.Credit {
+		dailyKind, monthlyKind = LimitDailyDeposit, LimitMonthlyDeposit
+	}
+	if daily != nil && usage.Daily.GreaterThan(*daily) {
+		return &LimitExceededError{Limit: dailyKind, Threshold: *daily, Attempted: usage.Daily}
+	}
+	if monthly != nil && usage.Monthly.GreaterThan(*monthly) {
+		return &LimitExceededError{Limit: monthlyKind, Threshold: *monthly, Attempted: usage.Monthly}
+	}
+	return nil
+}
+
+// CheckOpening проверяет начальный баланс нового кошелька как его первое пополнение.
+func (l Limits) CheckOpening(balance decimal.Decimal) error {
+	return l.Check(LimitUsage{Credit: true, Amount: balance, Balance: balance, Daily: balance, Monthly: balance})
+}
+
+// WalletLimits — собственные лимиты кошелька и лимиты, действующие с учётом значений по умолчанию.
+type WalletLimits struct {
+	WalletID  uuid.UUID `json:"walletId"`
+	Own       Limits    `json:"own"`
+	Effective Limits    `json:"effective"`
+}
+
+// LimitExceededError уточняет ErrLimitExceeded нарушенным лимитом.
+type LimitExceededError struct {
+	Limit     LimitKind       `json:"limit"`
+	Threshold decimal.Decimal `json:"threshold"`
+	Attempted decimal.Decimal `json:"attempted"`
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s limit %s exceeded: %s", e.Limit, e.Threshold, e.Attempted)
+}
+
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
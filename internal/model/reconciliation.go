@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const DefaultReconciliationBatchSize = 500
+
+// CreditOperationTypes увеличивают баланс кошелька, остальные типы операций его уменьшают.
+var CreditOperationTypes = []OperationType{Deposit, TransferIn, ExchangeIn, ReversalIn}
+
+// WalletReconciliation — баланс кошелька рядом с балансами, выведенными из журнала транзакций и главной книги.
+type WalletReconciliation struct {
+	WalletID            uuid.UUID       `json:"walletId"`
+	Balance             decimal.Decimal `json:"balance"`
+	TransactionsBalance decimal.Decimal `json:"transactionsBalance"`
+	LedgerBalance       decimal.Decimal `json:"ledgerBalance"`
+	Drift               decimal.Decimal `json:"drift"`
+}
+
+func (w *WalletReconciliation) Consistent() bool {
+	return w.Balance.Equal(w.TransactionsBalance) && w.Balance.Equal(w.LedgerBalance)
+}
+
+type ReconciliationReport struct {
+	RunID          uuid.UUID              `json:"runId"`
+	StartedAt      time.Time              `json:"startedAt"`
+	FinishedAt     time.Time              `json:"finishedAt"`
+	WalletsChecked int                    `json:"walletsChecked"`
+	Mismatches     []WalletReconciliation `json:"mismatches"`
+}
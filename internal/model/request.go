@@ -10,12 +10,50 @@ type OperationType string
 const (
 	Deposit  OperationType = "DEPOSIT"
 	Withdraw OperationType = "WITHDRAW"
+
+	// TransferOut и TransferIn — стороны перевода между кошельками, напрямую в /wallet не принимаются.
+	TransferOut OperationType = "TRANSFER_OUT"
+	TransferIn  OperationType = "TRANSFER_IN"
+
+	// ExchangeOut и ExchangeIn — стороны обмена между кошельками в разных валютах.
+	ExchangeOut OperationType = "EXCHANGE_OUT"
+	ExchangeIn  OperationType = "EXCHANGE_IN"
+
+	// ReversalOut отменяет зачисление, ReversalIn возвращает списанное.
+	ReversalOut OperationType = "REVERSAL_OUT"
+	ReversalIn  OperationType = "REVERSAL_IN"
 )
 
+// OperationTypes — все типы операций, которые пишутся в transactions; новый тип добавляется
+// сюда, иначе по нему нельзя будет отфильтровать историю.
+var OperationTypes = []OperationType{Deposit, Withdraw, TransferOut, TransferIn, ExchangeOut, ExchangeIn, ReversalOut, ReversalIn}
+
+// IsCredit сообщает, увеличивает ли операция баланс кошелька.
+func (o OperationType) IsCredit() bool {
+	for _, credit := range CreditOperationTypes {
+		if o == credit {
+			return true
+		}
+	}
+	return false
+}
+
+const MaxIdempotencyKeyLength = 255
+
 type Transaction struct {
 	WalletID      uuid.UUID       `json:"walletId"`
 	OperationType OperationType   `json:"operationType"`
 	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+
+	// IdempotencyKey приходит в заголовке Idempotency-Key, а не в теле запроса.
+	IdempotencyKey string `json:"-"`
+	// TransferID связывает две стороны перевода или обмена.
+	TransferID uuid.UUID `json:"-"`
+	// Exchange — курс, по которому выполнена сторона обмена.
+	Exchange *ExchangeRate `json:"-"`
+	// ReversalOf — отменяемая операция.
+	ReversalOf *uuid.UUID `json:"-"`
 }
 
 func (t *Transaction) ValidateWalletID() bool {
@@ -27,9 +65,43 @@ func (t *Transaction) ValidateOperationType() bool {
 }
 
 func (t *Transaction) ValidateAmount() bool {
-	return t.Amount.GreaterThan(decimal.Zero)
+	return t.Amount.GreaterThan(decimal.Zero) && ValidAmountIn(t.CurrencyCode(), t.Amount)
+}
+
+// CurrencyCode возвращает валюту операции; запросы без валюты считаются запросами в DefaultCurrency.
+func (t *Transaction) CurrencyCode() string {
+	if t.Currency == "" {
+		return DefaultCurrency
+	}
+	return t.Currency
+}
+
+func (t *Transaction) ValidateIdempotencyKey() bool {
+	return len(t.IdempotencyKey) <= MaxIdempotencyKeyLength
 }
 
 func (t *Transaction) Validate() bool {
-	return t.ValidateWalletID() && t.ValidateOperationType() && t.ValidateAmount()
+	return t.ValidateWalletID() && t.ValidateOperationType() && t.ValidateAmount() && t.ValidateIdempotencyKey()
+}
+
+// SamePayload сообщает, совпадает ли содержимое операции с ранее сохранённой под тем же ключом идемпотентности.
+func (t *Transaction) SamePayload(record TransactionRecord) bool {
+	return t.WalletID == record.WalletID && t.OperationType == record.OperationType &&
+		t.Amount.Equal(record.Amount) && t.CurrencyCode() == record.Currency &&
+		sameUUID(t.ReversalOf, record.ReversalOf)
+}
+
+func sameUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// SignedAmount возвращает изменение баланса: положительное для зачислений, отрицательное для списаний.
+func (t *Transaction) SignedAmount() decimal.Decimal {
+	if !t.OperationType.IsCredit() {
+		return t.Amount.Neg()
+	}
+	return t.Amount
 }
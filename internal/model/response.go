@@ -7,13 +7,65 @@ type Response struct {
 }
 
 const (
-	StatusBadRequest           = "Bad Request"
-	StatusInvalidRequestBody   = "Invalid request body"
-	StatusInvalidRequestData   = "Invalid request data. Please check the input parameters."
-	StatusInsufficientFunds    = "insufficient funds"
-	StatusWalletNotFound       = "Wallet with UUID %s not found"
-	StatusInternalServerError  = "Internal Server Error"
-	StatusTransactionSuccess   = "Transaction successful"
-	StatusWalletBalanceSuccess = "Wallet balance successfully received"
-	StatusInvalidUUIDFormat    = "Invalid wallet UUID format."
-)
\ No newline at end of file
+	StatusBadRequest             = "Bad Request"
+	StatusInvalidRequestBody     = "Invalid request body"
+	StatusInvalidRequestData     = "Invalid request data. Please check the input parameters."
+	StatusInsufficientFunds      = "insufficient funds"
+	StatusWalletNotFound         = "Wallet with UUID %s not found"
+	StatusInternalServerError    = "Internal Server Error"
+	StatusTransactionSuccess     = "Transaction successful"
+	StatusWalletBalanceSuccess   = "Wallet balance successfully received"
+	StatusInvalidUUIDFormat      = "Invalid wallet UUID format."
+	StatusWalletCreated          = "Wallet successfully created"
+	StatusWalletAlreadyExists    = "Wallet with this UUID already exists"
+	StatusIdempotencyKeyConflict = "Idempotency key has already been used with a different request"
+	StatusInvalidQueryParameters = "Invalid query parameters."
+	StatusTransactionsSuccess    = "Wallet transactions successfully received"
+	StatusTransferSuccess        = "Transfer successful"
+	StatusWalletNotFoundGeneric  = "Wallet not found"
+	StatusConflict               = "Request conflicts with the current state of the resource"
+	StatusRequestCanceled        = "Request canceled by client"
+	StatusRequestTimeout         = "Request timed out"
+	StatusAlive                  = "Service is alive"
+	StatusReady                  = "Service is ready"
+	StatusNotReady               = "Service is not ready"
+	StatusShuttingDown           = "Service is shutting down"
+	StatusCurrencyMismatch       = "Operation currency does not match the wallet currency"
+	StatusExchangeRateNotFound   = "No exchange rate for this currency pair"
+	StatusInvalidExchange        = "Exchange requires wallets in different currencies and an amount valid in both"
+	StatusExchangeSuccess        = "Exchange successful"
+	StatusHoldCreated            = "Hold successfully created"
+	StatusHoldSuccess            = "Hold successfully received"
+	StatusHoldCaptured           = "Hold successfully captured"
+	StatusHoldReleased           = "Hold successfully released"
+	StatusHoldNotFound           = "Hold not found"
+	StatusHoldNotActive          = "Hold is no longer active"
+	StatusCaptureExceedsHold     = "Capture amount exceeds the held amount"
+	StatusInvalidHoldUUIDFormat  = "Invalid hold UUID format."
+	StatusInvalidAmount          = "Amount is not valid for the wallet currency"
+	StatusWalletFrozen           = "Wallet is frozen: only deposits are allowed"
+	StatusWalletClosed           = "Wallet is closed"
+	StatusWalletNotEmpty         = "Wallet must have zero balance and no active holds to be closed"
+	StatusInvalidStatusChange    = "Wallet status transition is not allowed"
+	StatusWalletStatusChanged    = "Wallet status successfully changed"
+	StatusWalletStatusHistory    = "Wallet status history successfully received"
+	StatusLimitExceeded          = "Wallet limit %s exceeded"
+	StatusWalletLimitsSuccess    = "Wallet limits successfully received"
+	StatusWalletLimitsUpdated    = "Wallet limits successfully updated"
+	StatusInvalidLimits          = "Limits must match the wallet currency precision"
+	StatusReversalSuccess        = "Transaction successfully reversed"
+	StatusTransactionNotFound    = "Transaction not found"
+	StatusNotReversible          = "Only deposits and withdrawals can be reversed"
+	StatusAlreadyReversed        = "Transaction is already fully reversed"
+	StatusReversalExceedsAmount  = "Reversal amount exceeds the remaining transaction amount"
+	StatusInvalidTransactionUUID = "Invalid transaction UUID format."
+	StatusBatchSuccess           = "Batch processed"
+	StatusBatchRejected          = "Batch rolled back: operation %d failed: %s"
+	StatusInvalidBatchItem       = "Invalid batch operation at index %d"
+	StatusWalletsImported        = "Wallets successfully imported"
+	StatusImportRejected         = "Import rejected: %d invalid lines, nothing was imported"
+	StatusInvalidBulkFormat      = "Invalid format: expected csv or ndjson"
+	StatusLedgerCheck            = "Wallet ledger check completed"
+	StatusHistoricalBalance      = "Wallet balance at the requested time successfully received"
+	StatusBalanceBeforeCreation  = "Wallet did not exist at the requested time"
+)
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Reversal отменяет операцию полностью или, если задан amount, частично. Частичных
+// отмен может быть несколько, но в сумме не больше исходной операции.
+type Reversal struct {
+	TransactionID  uuid.UUID        `json:"-"`
+	Amount         *decimal.Decimal `json:"amount"`
+	IdempotencyKey string           `json:"-"`
+}
+
+func (r *Reversal) Validate() bool {
+	return r.TransactionID != uuid.Nil &&
+		(r.Amount == nil || r.Amount.GreaterThan(decimal.Zero)) &&
+		len(r.IdempotencyKey) <= MaxIdempotencyKeyLength
+}
+
+// ReversalOperation возвращает тип операции, отменяющей operation. Отменять можно только
+// пополнения и списания: у переводов и обменов есть вторая сторона в другом кошельке.
+func ReversalOperation(operation OperationType) (OperationType, bool) {
+	switch operation {
+	case Deposit:
+		return ReversalOut, true
+	case Withdraw:
+		return ReversalIn, true
+	}
+	return "", false
+}
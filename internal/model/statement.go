@@ -0,0 +1,64 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type StatementFormat string
+
+const (
+	StatementJSON StatementFormat = "json"
+	StatementCSV  StatementFormat = "csv"
+	StatementPDF  StatementFormat = "pdf"
+)
+
+// DefaultStatementPeriod — период выписки, если from не указан.
+const DefaultStatementPeriod = 30 * 24 * time.Hour
+
+func (f StatementFormat) Valid() bool {
+	return f == StatementJSON || f == StatementCSV || f == StatementPDF
+}
+
+// StatementPeriod — запрос выписки за [From, To).
+type StatementPeriod struct {
+	WalletID uuid.UUID
+	From     time.Time
+	To       time.Time
+	Format   StatementFormat
+}
+
+func (p *StatementPeriod) Validate() bool {
+	return p.WalletID != uuid.Nil && p.From.Before(p.To) && p.Format.Valid()
+}
+
+// Statement — итоги выписки. Они считаются до перечисления операций, поэтому заголовок
+// выписки можно отдать клиенту раньше, чем прочитана первая операция.
+type Statement struct {
+	WalletID         uuid.UUID       `json:"walletId"`
+	OwnerID          *string         `json:"ownerId,omitempty"`
+	Currency         string          `json:"currency"`
+	From             time.Time       `json:"from"`
+	To               time.Time       `json:"to"`
+	OpeningBalance   decimal.Decimal `json:"openingBalance"`
+	TotalCredits     decimal.Decimal `json:"totalCredits"`
+	TotalDebits      decimal.Decimal `json:"totalDebits"`
+	ClosingBalance   decimal.Decimal `json:"closingBalance"`
+	TransactionCount int64           `json:"transactionCount"`
+	GeneratedAt      time.Time       `json:"generatedAt"`
+}
+
+// StatementLine — операция выписки с балансом после неё, восстановленным от входящего остатка.
+type StatementLine struct {
+	TransactionRecord
+	Balance decimal.Decimal `json:"balance"`
+}
+
+// StatementWriter выводит выписку в одном из форматов по мере чтения операций.
+type StatementWriter interface {
+	Begin(statement Statement) error
+	Line(line StatementLine) error
+	End(statement Statement) error
+}
@@ -0,0 +1,130 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+const (
+	DefaultTransactionsLimit = 50
+	MaxTransactionsLimit     = 100
+)
+
+type TransactionRecord struct {
+	UUID          uuid.UUID        `json:"uuid"`
+	WalletID      uuid.UUID        `json:"walletId"`
+	OperationType OperationType    `json:"operationType"`
+	Amount        decimal.Decimal  `json:"amount"`
+	Currency      string           `json:"currency"`
+	BalanceAfter  *decimal.Decimal `json:"balanceAfter,omitempty"`
+	TransferID    *uuid.UUID       `json:"transferId,omitempty"`
+	ExchangeRate  *decimal.Decimal `json:"exchangeRate,omitempty"`
+	Spread        *decimal.Decimal `json:"spread,omitempty"`
+	ReversalOf    *uuid.UUID       `json:"reversalOf,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// SignedAmount возвращает изменение баланса: положительное для зачислений, отрицательное для списаний.
+func (r *TransactionRecord) SignedAmount() decimal.Decimal {
+	if !r.OperationType.IsCredit() {
+		return r.Amount.Neg()
+	}
+	return r.Amount
+}
+
+type TransactionList struct {
+	Transactions []TransactionRecord `json:"transactions"`
+	NextCursor   string              `json:"nextCursor,omitempty"`
+}
+
+// TransactionCursor — позиция для keyset-пагинации по (created_at, uuid).
+type TransactionCursor struct {
+	CreatedAt time.Time
+	UUID      uuid.UUID
+}
+
+func (c TransactionCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.UUID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseTransactionCursor(s string) (TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return TransactionCursor{}, err
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), "|")
+	if !found {
+		return TransactionCursor{}, errors.New("malformed cursor")
+	}
+
+	var cursor TransactionCursor
+	if cursor.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return TransactionCursor{}, err
+	}
+	if cursor.UUID, err = uuid.Parse(id); err != nil {
+		return TransactionCursor{}, err
+	}
+	return cursor, nil
+}
+
+type TransactionFilter struct {
+	WalletID      uuid.UUID
+	OperationType OperationType
+	MinAmount     *decimal.Decimal
+	MaxAmount     *decimal.Decimal
+	From          *time.Time
+	To            *time.Time
+	Order         SortOrder
+	Limit         int
+	Cursor        *TransactionCursor
+}
+
+func (f *TransactionFilter) ValidateOperationType() bool {
+	if f.OperationType == "" {
+		return true
+	}
+	for _, operation := range OperationTypes {
+		if f.OperationType == operation {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *TransactionFilter) ValidateAmountRange() bool {
+	if f.MinAmount != nil && f.MinAmount.IsNegative() || f.MaxAmount != nil && f.MaxAmount.IsNegative() {
+		return false
+	}
+	return f.MinAmount == nil || f.MaxAmount == nil || f.MinAmount.LessThanOrEqual(*f.MaxAmount)
+}
+
+func (f *TransactionFilter) ValidateTimeWindow() bool {
+	return f.From == nil || f.To == nil || !f.From.After(*f.To)
+}
+
+func (f *TransactionFilter) ValidateOrder() bool {
+	return f.Order == SortAsc || f.Order == SortDesc
+}
+
+func (f *TransactionFilter) ValidateLimit() bool {
+	return f.Limit > 0 && f.Limit <= MaxTransactionsLimit
+}
+
+func (f *TransactionFilter) Validate() bool {
+	return f.WalletID != uuid.Nil && f.ValidateOperationType() && f.ValidateAmountRange() &&
+		f.ValidateTimeWindow() && f.ValidateOrder() && f.ValidateLimit()
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type Transfer struct {
+	FromWalletID uuid.UUID       `json:"fromWalletId"`
+	ToWalletID   uuid.UUID       `json:"toWalletId"`
+	Amount       decimal.Decimal `json:"amount"`
+	Currency     string          `json:"currency"`
+}
+
+type TransferResult struct {
+	TransferID        uuid.UUID `json:"transferId"`
+	FromTransactionID uuid.UUID `json:"fromTransactionId"`
+	ToTransactionID   uuid.UUID `json:"toTransactionId"`
+}
+
+func (t *Transfer) ValidateWalletIDs() bool {
+	return t.FromWalletID != uuid.Nil && t.ToWalletID != uuid.Nil && t.FromWalletID != t.ToWalletID
+}
+
+func (t *Transfer) ValidateAmount() bool {
+	return t.Amount.GreaterThan(decimal.Zero) && ValidAmountIn(t.CurrencyCode(), t.Amount)
+}
+
+func (t *Transfer) CurrencyCode() string {
+	if t.Currency == "" {
+		return DefaultCurrency
+	}
+	return t.Currency
+}
+
+func (t *Transfer) Validate() bool {
+	return t.ValidateWalletIDs() && t.ValidateAmount()
+}
+
+// Legs разбивает перевод на списание и зачисление, связанные общим transferID.
+func (t *Transfer) Legs(transferID uuid.UUID) (debit Transaction, credit Transaction) {
+	debit = Transaction{
+		WalletID:      t.FromWalletID,
+		OperationType: TransferOut,
+		Amount:        t.Amount,
+		Currency:      t.CurrencyCode(),
+		TransferID:    transferID,
+	}
+	credit = Transaction{
+		WalletID:      t.ToWalletID,
+		OperationType: TransferIn,
+		Amount:        t.Amount,
+		Currency:      t.CurrencyCode(),
+		TransferID:    transferID,
+	}
+	return debit, credit
+}
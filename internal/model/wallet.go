@@ -7,8 +7,41 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+const MaxOwnerIDLength = 255
+
 type Wallet struct {
-	UUID      uuid.UUID       `json:"uuid"`
-	Balance   decimal.Decimal `json:"balance"`
-	CreatedAt time.Time       `json:"created_at"`
+	UUID    uuid.UUID       `json:"uuid"`
+	OwnerID *string         `json:"ownerId,omitempty"`
+	Balance decimal.Decimal `json:"balance"`
+	// AvailableBalance — баланс за вычетом активных холдов.
+	AvailableBalance decimal.Decimal `json:"availableBalance"`
+	Currency         string          `json:"currency"`
+	Status           WalletStatus    `json:"status"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+type WalletCreation struct {
+	UUID     uuid.UUID       `json:"uuid"`
+	OwnerID  *string         `json:"ownerId"`
+	Balance  decimal.Decimal `json:"balance"`
+	Currency string          `json:"currency"`
+}
+
+func (w *WalletCreation) ValidateOwnerID() bool {
+	return w.OwnerID == nil || (*w.OwnerID != "" && len(*w.OwnerID) <= MaxOwnerIDLength)
+}
+
+func (w *WalletCreation) ValidateBalance() bool {
+	return !w.Balance.IsNegative() && ValidAmountIn(w.CurrencyCode(), w.Balance)
+}
+
+func (w *WalletCreation) CurrencyCode() string {
+	if w.Currency == "" {
+		return DefaultCurrency
+	}
+	return w.Currency
+}
+
+func (w *WalletCreation) Validate() bool {
+	return w.ValidateOwnerID() && w.ValidateBalance()
 }
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"fmt"
+)
+
+// BulkFormat — формат потока массового импорта и экспорта кошельков.
+type BulkFormat string
+
+const (
+	BulkCSV    BulkFormat = "csv"
+	BulkNDJSON BulkFormat = "ndjson"
+)
+
+// MaxImportErrors ограничивает число ошибок в отчёте об импорте: файл, испорченный
+// целиком, не должен порождать отчёт размером с сам файл.
+const MaxImportErrors = 100
+
+func (f BulkFormat) Valid() bool {
+	return f == BulkCSV || f == BulkNDJSON
+}
+
+// WalletImportRow — проверенная строка импорта; UUID кошелька уже заполнен.
+type WalletImportRow struct {
+	Line   int64
+	Wallet WalletCreation
+}
+
+// WalletImportSource отдаёт проверенные строки импорта по одной. Err возвращает
+// *WalletImportError, если в потоке нашлись некорректные строки.
+type WalletImportSource interface {
+	Next() bool
+	Row() WalletImportRow
+	Err() error
+}
+
+type ImportLineError struct {
+	Line    int64  `json:"line"`
+	Message string `json:"message"`
+}
+
+// WalletImportError перечисляет отклонённые строки; импорт с такой ошибкой не записывает ничего.
+type WalletImportError struct {
+	Errors []ImportLineError `json:"errors"`
+	// Truncated — ошибок больше MaxImportErrors, и проверка остановлена на последней из них.
+	Truncated bool `json:"truncated"`
+}
+
+func (e *WalletImportError) Error() string {
+	return fmt.Sprintf("wallet import rejected: %d invalid lines", len(e.Errors))
+}
+
+func (e *WalletImportError) Is(target error) bool {
+	return target == ErrInvalidImport
+}
+
+type WalletImportResult struct {
+	Format   BulkFormat `json:"format"`
+	Imported int64      `json:"imported"`
+}
@@ -0,0 +1,84 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WalletStatus string
+
+const (
+	WalletActive WalletStatus = "ACTIVE"
+	// WalletFrozen принимает зачисления, но не списания.
+	WalletFrozen WalletStatus = "FROZEN"
+	// WalletClosed не принимает никаких операций; закрыть можно только пустой кошелёк.
+	WalletClosed WalletStatus = "CLOSED"
+)
+
+var WalletStatuses = []WalletStatus{WalletActive, WalletFrozen, WalletClosed}
+
+const (
+	MaxStatusReasonLength = 1000
+	MaxActorLength        = 255
+)
+
+func (s WalletStatus) Valid() bool {
+	for _, status := range WalletStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Permit возвращает ошибку, если статус кошелька запрещает операцию.
+func (s WalletStatus) Permit(operation OperationType) error {
+	switch {
+	case s == WalletClosed:
+		return ErrWalletClosed
+	case s == WalletFrozen && !operation.IsCredit():
+		return ErrWalletFrozen
+	}
+	return nil
+}
+
+// CanTransitionTo сообщает, допустим ли переход: закрытие окончательно.
+func (s WalletStatus) CanTransitionTo(next WalletStatus) bool {
+	return s != WalletClosed && s != next
+}
+
+// StatusesAllowing возвращает статусы кошелька, в которых Permit разрешает операцию.
+func StatusesAllowing(operation OperationType) []WalletStatus {
+	var allowed []WalletStatus
+	for _, status := range WalletStatuses {
+		if status.Permit(operation) == nil {
+			allowed = append(allowed, status)
+		}
+	}
+	return allowed
+}
+
+// WalletStatusChange — запрос администратора на смену статуса; WalletID берётся из пути.
+type WalletStatusChange struct {
+	WalletID uuid.UUID    `json:"-"`
+	Status   WalletStatus `json:"status"`
+	Reason   string       `json:"reason"`
+	Actor    string       `json:"actor"`
+}
+
+func (c *WalletStatusChange) Validate() bool {
+	return c.WalletID != uuid.Nil && c.Status.Valid() &&
+		c.Reason != "" && len(c.Reason) <= MaxStatusReasonLength &&
+		c.Actor != "" && len(c.Actor) <= MaxActorLength
+}
+
+type WalletStatusAudit struct {
+	ID         int64        `json:"id"`
+	WalletID   uuid.UUID    `json:"walletId"`
+	FromStatus WalletStatus `json:"fromStatus"`
+	ToStatus   WalletStatus `json:"toStatus"`
+	Reason     string       `json:"reason"`
+	Actor      string       `json:"actor"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
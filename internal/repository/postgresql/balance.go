@@ -0,0 +1,159 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// signedAmountSum — сумма операций со знаком: зачисления плюс, списания минус.
+func signedAmountSum(alias string) (string, []any, error) {
+	credit, args, err := squirrel.Eq{alias + ".transaction_type": model.CreditOperationTypes}.ToSql()
+	if err != nil {
+		return "", nil, err
+	}
+	return "COALESCE(SUM(CASE WHEN " + credit + " THEN " + alias + ".amount ELSE -" + alias + ".amount END), 0)", args, nil
+}
+
+// GetBalanceAt восстанавливает баланс на момент at по журналу транзакций. Отсчёт идёт
+// от ближайшего снимка: к предыдущему прибавляются операции после него, из следующего
+// вычитаются операции до него, так что при ежедневных снимках читаются операции не больше
+// чем за сутки.
+// Без снимков из текущего баланса вычитаются все операции после at. Все запросы
+// выполняются в одном снимке REPEATABLE READ.
+func (r *WalletRepo) GetBalanceAt(ctx context.Context, UUID uuid.UUID, at time.Time) (balance model.HistoricalBalance, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.GetBalanceAt")
+	defer func() { tracing.End(span, err) }()
+	log := r.logger(ctx).WithField(logger.FieldWalletUUID, UUID)
+
+	tx, err := r.PgxPool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		log.Errorf("Failed to begin transaction: %v", err)
+		return model.HistoricalBalance{}, err
+	}
+	defer r.rollback(ctx, tx)
+
+	sql, args, err := Builder().Select("w.currency", "w.balance", "w.created_at",
+		"prior_snapshot.snapshot_at", "prior_snapshot.balance", "next_snapshot.snapshot_at", "next_snapshot.balance").
+		From("wallets w").
+		JoinClause("LEFT JOIN LATERAL (SELECT snapshot_at, balance FROM wallet_balance_snapshots s"+
+			" WHERE s.wallet_uuid = w.uuid AND s.snapshot_at <= ? ORDER BY snapshot_at DESC LIMIT 1) prior_snapshot ON true", at).
+		JoinClause("LEFT JOIN LATERAL (SELECT snapshot_at, balance FROM wallet_balance_snapshots s"+
+			" WHERE s.wallet_uuid = w.uuid AND s.snapshot_at > ? ORDER BY snapshot_at LIMIT 1) next_snapshot ON true", at).
+		Where(squirrel.Eq{"w.uuid": UUID}).ToSql()
+	if err != nil {
+		log.Errorf("Failed to build query for GetBalanceAt: %v", err)
+		return model.HistoricalBalance{}, err
+	}
+
+	var (
+		current                   decimal.Decimal
+		createdAt                 time.Time
+		priorAt, nextAt           *time.Time
+		priorBalance, nextBalance *decimal.Decimal
+	)
+	balance = model.HistoricalBalance{WalletID: UUID, At: at}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&balance.Currency, &current, &createdAt,
+		&priorAt, &priorBalance, &nextAt, &nextBalance)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.HistoricalBalance{}, model.NewWalletNotFoundError(UUID)
+	}
+	if err != nil {
+		log.Errorf("Error reading wallet snapshots: %v", err)
+		return model.HistoricalBalance{}, err
+	}
+	if at.Before(createdAt) {
+		return model.HistoricalBalance{}, model.ErrBalanceBeforeCreation
+	}
+
+	window := squirrel.And{squirrel.Eq{"t.wallet_uuid": UUID}}
+	base, forward := current, false
+	switch {
+	case priorAt != nil:
+		base, forward, balance.SnapshotAt = *priorBalance, true, priorAt
+		window = append(window, squirrel.GtOrEq{"t.created_at": *priorAt}, squirrel.LtOrEq{"t.created_at": at})
+	case nextAt != nil:
+		base, balance.SnapshotAt = *nextBalance, nextAt
+		window = append(window, squirrel.Gt{"t.created_at": at}, squirrel.Lt{"t.created_at": *nextAt})
+	default:
+		window = append(window, squirrel.Gt{"t.created_at": at})
+	}
+
+	sum, sumArgs, err := signedAmountSum("t")
+	if err != nil {
+		log.Errorf("Failed to build credit condition for GetBalanceAt: %v", err)
+		return model.HistoricalBalance{}, err
+	}
+	sql, args, err = Builder().Select().Column(squirrel.Expr(sum, sumArgs...)).
+		From("transactions t").
+		Where(window).ToSql()
+	if err != nil {
+		log.Errorf("Failed to build query for GetBalanceAt: %v", err)
+		return model.HistoricalBalance{}, err
+	}
+
+	var delta decimal.Decimal
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&delta); err != nil {
+		log.Errorf("Error summing transactions for GetBalanceAt: %v", err)
+		return model.HistoricalBalance{}, err
+	}
+
+	if forward {
+		balance.Balance = base.Add(delta)
+	} else {
+		balance.Balance = base.Sub(delta)
+	}
+	return balance, nil
+}
+
+// balanceSnapshotQuery снимает балансы пачки кошельков и возвращает последний UUID пачки
+// и число созданных снимков; %s — сумма операций со знаком.
+const balanceSnapshotQuery = `WITH batch AS (
+	SELECT uuid, balance FROM wallets WHERE uuid > ? AND created_at < ? ORDER BY uuid LIMIT ?
+), inserted AS (
+	INSERT INTO wallet_balance_snapshots (wallet_uuid, snapshot_at, balance)
+	SELECT b.uuid, ?, b.balance - (SELECT %s FROM transactions t WHERE t.wallet_uuid = b.uuid AND t.created_at >= ?)
+	FROM batch b
+	ON CONFLICT DO NOTHING
+	RETURNING 1
+)
+SELECT (SELECT uuid FROM batch ORDER BY uuid DESC LIMIT 1), (SELECT count(*) FROM inserted)`
+
+// CreateBalanceSnapshots снимает балансы на момент at для пачки кошельков с UUID больше after,
+// созданных раньше at. Баланс снимка выводится из текущего вычитанием операций с created_at
+// не раньше at; существующие снимки не перезаписываются, поэтому повторный запуск безопасен.
+func (r *WalletRepo) CreateBalanceSnapshots(ctx context.Context, at time.Time, after uuid.UUID, limit int) (model.BalanceSnapshotBatch, error) {
+	sum, sumArgs, err := signedAmountSum("t")
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build credit condition for CreateBalanceSnapshots: %v", err)
+		return model.BalanceSnapshotBatch{}, err
+	}
+
+	sql, err := squirrel.Dollar.ReplacePlaceholders(fmt.Sprintf(balanceSnapshotQuery, sum))
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for CreateBalanceSnapshots: %v", err)
+		return model.BalanceSnapshotBatch{}, err
+	}
+	args := append([]any{after, at, limit, at}, sumArgs...)
+	args = append(args, at)
+
+	var last *uuid.UUID
+	var result model.BalanceSnapshotBatch
+	if err = r.PgxPool.QueryRow(ctx, sql, args...).Scan(&last, &result.Created); err != nil {
+		r.logger(ctx).Errorf("Error creating balance snapshots: %v", err)
+		return model.BalanceSnapshotBatch{}, err
+	}
+	if last != nil {
+		result.LastWalletID = *last
+	}
+	return result, nil
+}
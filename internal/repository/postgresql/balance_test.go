@@ -0,0 +1,136 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/repository/postgresql/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectBalanceAt ожидает чтение кошелька со снимками и сумму операций delta.
+func expectBalanceAt(ctrl *gomock.Controller, mockPool *mock.MockPgxPool, createdAt time.Time,
+	prior, next *time.Time, snapshot decimal.Decimal, delta int32) {
+	mockTx := mock.NewMockTx(ctrl)
+	walletRow := mock.NewMockRow(ctrl)
+	sumRow := mock.NewMockRow(ctrl)
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}).Return(mockTx, nil)
+	gomock.InOrder(
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(walletRow),
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(sumRow),
+	)
+	walletRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "RUB"
+		*dest[1].(*decimal.Decimal) = decimal.NewFromInt32(500)
+		*dest[2].(*time.Time) = createdAt
+		if prior != nil {
+			*dest[3].(**time.Time) = prior
+			*dest[4].(**decimal.Decimal) = &snapshot
+		}
+		if next != nil {
+			*dest[5].(**time.Time) = next
+			*dest[6].(**decimal.Decimal) = &snapshot
+		}
+		return nil
+	})
+	sumRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*decimal.Decimal) = decimal.NewFromInt32(delta)
+		return nil
+	})
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+}
+
+func TestWalletRepo_GetBalanceAt(t *testing.T) {
+	at := time.Date(2026, 5, 10, 15, 0, 0, 0, time.UTC)
+	createdAt := at.Add(-30 * 24 * time.Hour)
+	midnight := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	nextMidnight := midnight.Add(model.SnapshotInterval)
+
+	tests := []struct {
+		name        string
+		prior, next *time.Time
+		delta       int32
+		expected    int64
+		snapshotAt  *time.Time
+	}{
+		// Снимок 300 на полночь, после него до at проведено +40.
+		{"forward from prior snapshot", &midnight, nil, 40, 340, &midnight},
+		// Снимок 300 на следующую полночь, между at и ним проведено +40.
+		{"backward from next snapshot", nil, &nextMidnight, 40, 260, &nextMidnight},
+		// Текущий баланс 500, после at проведено +40.
+		{"backward from current balance", nil, nil, 40, 460, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPool := mock.NewMockPgxPool(ctrl)
+			expectBalanceAt(ctrl, mockPool, createdAt, tt.prior, tt.next, decimal.NewFromInt32(300), tt.delta)
+
+			repo := NewWalletRepo(mockPool, logger.Discard())
+			walletID := uuid.New()
+			balance, err := repo.GetBalanceAt(context.Background(), walletID, at)
+
+			assert.NoError(t, err)
+			assert.Equal(t, walletID, balance.WalletID)
+			assert.Equal(t, "RUB", balance.Currency)
+			assert.True(t, decimal.NewFromInt(tt.expected).Equal(balance.Balance), balance.Balance.String())
+			assert.Equal(t, tt.snapshotAt, balance.SnapshotAt)
+		})
+	}
+}
+
+func TestWalletRepo_GetBalanceAt_BeforeCreation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+	at := time.Now().UTC().Add(-time.Hour)
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[2].(*time.Time) = at.Add(time.Minute)
+		return nil
+	})
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+	_, err := repo.GetBalanceAt(context.Background(), uuid.New(), at)
+
+	assert.ErrorIs(t, err, model.ErrBalanceBeforeCreation)
+}
+
+func TestWalletRepo_GetBalanceAt_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+	walletID := uuid.New()
+	_, err := repo.GetBalanceAt(context.Background(), walletID, time.Now())
+
+	var notFound *model.WalletNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, walletID, notFound.UUID)
+}
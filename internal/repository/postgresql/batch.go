@@ -0,0 +1,434 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// batchWallet — заблокированный кошелёк, на копии которого пакет проверяется до записи.
+// Проверки повторяют условный UPDATE и enforceLimits в том же порядке.
+type batchWallet struct {
+	balance  decimal.Decimal
+	held     decimal.Decimal
+	currency string
+	status   model.WalletStatus
+	limits   model.Limits
+	// daily и monthly — суммы за окна лимитов по направлению; true — зачисления.
+	daily, monthly map[bool]decimal.Decimal
+	delta          decimal.Decimal
+}
+
+func (w *batchWallet) apply(transaction model.Transaction) (decimal.Decimal, error) {
+	if err := w.status.Permit(transaction.OperationType); err != nil {
+		return decimal.Zero, err
+	}
+	if w.currency != transaction.CurrencyCode() {
+		return decimal.Zero, model.ErrCurrencyMismatch
+	}
+	balance := w.balance.Add(transaction.SignedAmount())
+	if balance.LessThan(w.held) {
+		return decimal.Zero, model.ErrInsufficientFunds
+	}
+
+	credit := transaction.OperationType.IsCredit()
+	usage := model.LimitUsage{
+		Credit:   credit,
+		Reversal: transaction.ReversalOf != nil,
+		Amount:   transaction.Amount,
+		Balance:  balance,
+		Daily:    w.daily[credit].Add(transaction.Amount),
+		Monthly:  w.monthly[credit].Add(transaction.Amount),
+	}
+	if err := w.limits.Check(usage); err != nil {
+		return decimal.Zero, err
+	}
+
+	w.balance = balance
+	w.delta = w.delta.Add(transaction.SignedAmount())
+	w.daily[credit], w.monthly[credit] = usage.Daily, usage.Monthly
+	return balance, nil
+}
+
+// ProcessBatch выполняет пакет в одной транзакции БД. Кошельки пакета блокируются сразу,
+// поэтому операции проверяются в памяти, а записываются одним pgx.Batch: по строке в
+// transactions и проводке на операцию и по одному UPDATE на кошелёк. В атомарном режиме
+// ошибка любой операции откатывает пакет, в режиме best_effort пропускается только она.
+// Операции пакета с ключом идемпотентности, записанные прежде, не выполняются повторно.
+func (r *WalletRepo) ProcessBatch(ctx context.Context, request model.BatchRequest) (model.BatchResult, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.ProcessBatch")
+	result, err := r.processBatch(ctx, request)
+
+	var pgErr *pgconn.PgError
+	if request.IdempotencyKey != "" && errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		// Параллельный пакет с тем же ключом зафиксировался раньше — повтор отдаст его операции.
+		r.logger(ctx).Infof("Concurrent batch with idempotency key %s already committed", request.IdempotencyKey)
+		result, err = r.processBatch(ctx, request)
+	}
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *WalletRepo) processBatch(ctx context.Context, request model.BatchRequest) (result model.BatchResult, err error) {
+	log := r.logger(ctx).WithFields(logrus.Fields{
+		"batch_mode": request.BatchMode(),
+		"batch_size": len(request.Transactions),
+	})
+
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Failed to begin transaction: %v", err)
+		return model.BatchResult{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			r.rollback(ctx, tx)
+			log.Warnf("Transaction rolled back due to error: %v", err)
+		}
+	}()
+
+	walletIDs := request.WalletIDs()
+	wallets, err := r.loadBatchWallets(ctx, walletIDs, tx)
+	if err != nil {
+		return model.BatchResult{}, err
+	}
+
+	replayed, err := r.replayBatch(ctx, request, tx)
+	if err != nil {
+		return model.BatchResult{}, err
+	}
+
+	result = model.BatchResult{Mode: request.BatchMode(), Items: make([]model.BatchItemResult, len(request.Transactions))}
+	for i, transaction := range request.Transactions {
+		item := &result.Items[i]
+		item.Index = i
+
+		if record, ok := replayed[i]; ok {
+			item.Status, item.Transaction = model.BatchItemSucceeded, &record
+			continue
+		}
+		wallet, ok := wallets[transaction.WalletID]
+		if !ok {
+			item.Status, item.Err = model.BatchItemFailed, model.NewWalletNotFoundError(transaction.WalletID)
+			continue
+		}
+		balance, applyErr := wallet.apply(transaction)
+		if applyErr != nil {
+			item.Status, item.Err = model.BatchItemFailed, applyErr
+			continue
+		}
+		item.Status = model.BatchItemSucceeded
+		item.Transaction = &model.TransactionRecord{
+			UUID:          uuid.New(),
+			WalletID:      transaction.WalletID,
+			OperationType: transaction.OperationType,
+			Amount:        transaction.Amount,
+			Currency:      transaction.CurrencyCode(),
+			BalanceAfter:  &balance,
+		}
+	}
+
+	if rejected, ok := result.Rejected(); ok {
+		for i := range result.Items {
+			if result.Items[i].Status == model.BatchItemSucceeded {
+				result.Items[i].Status, result.Items[i].Transaction = model.BatchItemRolledBack, nil
+			}
+		}
+		result.Tally()
+		r.rollback(ctx, tx)
+		log.Infof("Atomic batch rejected at operation %d: %v", rejected.Index, rejected.Err)
+		return result, nil
+	}
+
+	if err = r.writeBatch(ctx, request, &result, replayed, walletIDs, wallets, tx); err != nil {
+		return model.BatchResult{}, err
+	}
+
+	if err = r.commit(ctx, tx); err != nil {
+		log.Errorf("Failed to commit transaction: %v", err)
+		return model.BatchResult{}, err
+	}
+	result.Tally()
+	return result, nil
+}
+
+// loadBatchWallets одним pgx.Batch блокирует кошельки в порядке UUID и читает их лимиты
+// и суммы операций за окна лимитов. Отсутствующих кошельков в результате нет.
+func (r *WalletRepo) loadBatchWallets(ctx context.Context, UUIDs []uuid.UUID, tx pgx.Tx) (map[uuid.UUID]*batchWallet, error) {
+	lockSQL, lockArgs, err := Builder().Select("uuid", "balance", "held", "currency", "status").
+		From("wallets").
+		Where(squirrel.Eq{"uuid": UUIDs}).
+		OrderBy("uuid").
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build lock query for loadBatchWallets: %v", err)
+		return nil, err
+	}
+	limitsSQL, limitsArgs, err := Builder().Select(append([]string{"wallet_uuid"}, limitColumns...)...).
+		From("wallet_limits").
+		Where(squirrel.Eq{"wallet_uuid": UUIDs}).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build limits query for loadBatchWallets: %v", err)
+		return nil, err
+	}
+	credit, creditArgs, err := squirrel.Eq{"t.transaction_type": model.VelocityOperationTypes(true)}.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	totalsSQL, totalsArgs, err := Builder().Select("t.wallet_uuid").
+		Column(squirrel.Expr(credit, creditArgs...)).
+		Column(squirrel.Expr("COALESCE(SUM("+velocityAmount+") FILTER (WHERE t.created_at > CURRENT_TIMESTAMP - make_interval(secs => ?)), 0)",
+			model.DailyLimitWindow.Seconds())).
+		Column("COALESCE(SUM("+velocityAmount+"), 0)").
+		From("transactions t").
+		Where(squirrel.Eq{"t.wallet_uuid": UUIDs}).
+		Where(squirrel.NotEq{"t.transaction_type": []model.OperationType{model.ReversalOut, model.ReversalIn}}).
+		Where(squirrel.Expr("t.created_at > CURRENT_TIMESTAMP - make_interval(secs => ?)", model.MonthlyLimitWindow.Seconds())).
+		GroupBy("1", "2").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build totals query for loadBatchWallets: %v", err)
+		return nil, err
+	}
+
+	batch := &pgx.Batch{}
+	batch.Queue(lockSQL, lockArgs...)
+	batch.Queue(limitsSQL, limitsArgs...)
+	batch.Queue(totalsSQL, totalsArgs...)
+	results := tx.SendBatch(ctx, batch)
+	defer results.Close()
+
+	wallets := make(map[uuid.UUID]*batchWallet, len(UUIDs))
+	err = scanBatchRows(results, func(rows pgx.Rows) error {
+		var UUID uuid.UUID
+		wallet := &batchWallet{
+			daily:   make(map[bool]decimal.Decimal, 2),
+			monthly: make(map[bool]decimal.Decimal, 2),
+		}
+		if err := rows.Scan(&UUID, &wallet.balance, &wallet.held, &wallet.currency, &wallet.status); err != nil {
+			return err
+		}
+		wallets[UUID] = wallet
+		return nil
+	})
+	if err != nil {
+		r.logger(ctx).Errorf("Error locking batch wallets: %v", err)
+		return nil, err
+	}
+
+	own := make(map[uuid.UUID]model.Limits, len(UUIDs))
+	err = scanBatchRows(results, func(rows pgx.Rows) error {
+		var (
+			UUID   uuid.UUID
+			limits model.Limits
+		)
+		if err := rows.Scan(append([]any{&UUID}, limitDest(&limits)...)...); err != nil {
+			return err
+		}
+		own[UUID] = limits
+		return nil
+	})
+	if err != nil {
+		r.logger(ctx).Errorf("Error reading batch wallet limits: %v", err)
+		return nil, err
+	}
+
+	err = scanBatchRows(results, func(rows pgx.Rows) error {
+		var (
+			UUID           uuid.UUID
+			isCredit       bool
+			daily, monthly decimal.Decimal
+		)
+		if err := rows.Scan(&UUID, &isCredit, &daily, &monthly); err != nil {
+			return err
+		}
+		if wallet, ok := wallets[UUID]; ok {
+			wallet.daily[isCredit], wallet.monthly[isCredit] = daily, monthly
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger(ctx).Errorf("Error reading batch rolling totals: %v", err)
+		return nil, err
+	}
+
+	for UUID, wallet := range wallets {
+		wallet.limits = r.DefaultLimits.For(wallet.currency).Override(own[UUID])
+	}
+	return wallets, results.Close()
+}
+
+func scanBatchRows(results pgx.BatchResults, scan func(pgx.Rows) error) error {
+	rows, err := results.Query()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// replayBatch возвращает по индексу операции пакета, уже записанные под его ключом
+// идемпотентности. Операция с другим содержимым или частично записанный атомарный пакет
+// означают, что ключ использован для другого запроса.
+func (r *WalletRepo) replayBatch(ctx context.Context, request model.BatchRequest, tx pgx.Tx) (map[int]model.TransactionRecord, error) {
+	if request.IdempotencyKey == "" {
+		return nil, nil
+	}
+
+	indexes := make(map[string]int, len(request.Transactions))
+	for i := range request.Transactions {
+		indexes[request.ItemIdempotencyKey(i)] = i
+	}
+	keys := make([]string, 0, len(indexes))
+	for key := range indexes {
+		keys = append(keys, key)
+	}
+
+	sql, args, err := Builder().Select(append([]string{"idempotency_key"}, transactionColumns...)...).
+		From("transactions").
+		Where(squirrel.Eq{"idempotency_key": keys}).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for replayBatch: %v", err)
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		r.logger(ctx).Errorf("Error executing query for replayBatch with key %s: %v", request.IdempotencyKey, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	replayed := make(map[int]model.TransactionRecord)
+	for rows.Next() {
+		var (
+			key    string
+			record model.TransactionRecord
+		)
+		if err = rows.Scan(append([]any{&key}, transactionDest(&record)...)...); err != nil {
+			r.logger(ctx).Errorf("Error scanning batch transaction: %v", err)
+			return nil, err
+		}
+		index := indexes[key]
+		if !request.Transactions[index].SamePayload(record) {
+			r.logger(ctx).WithField(logger.FieldTransactionUUID, record.UUID).
+				Warnf("Idempotency key %s reused with a different batch", request.IdempotencyKey)
+			return nil, model.ErrIdempotencyKeyConflict
+		}
+		replayed[index] = record
+	}
+	if err = rows.Err(); err != nil {
+		r.logger(ctx).Errorf("Error reading batch transactions: %v", err)
+		return nil, err
+	}
+
+	// Атомарный пакет записывается целиком, поэтому часть операций может найтись, только если
+	// под тем же ключом прошёл пакет другого состава или режима.
+	if request.BatchMode() == model.BatchAtomic && len(replayed) > 0 && len(replayed) < len(request.Transactions) {
+		r.logger(ctx).Warnf("Idempotency key %s reused with a different batch", request.IdempotencyKey)
+		return nil, model.ErrIdempotencyKeyConflict
+	}
+	return replayed, nil
+}
+
+// writeBatch записывает принятые операции, кроме повторённых, и итоговые изменения
+// балансов одним pgx.Batch.
+func (r *WalletRepo) writeBatch(ctx context.Context, request model.BatchRequest, result *model.BatchResult, replayed map[int]model.TransactionRecord,
+	walletIDs []uuid.UUID, wallets map[uuid.UUID]*batchWallet, tx pgx.Tx) error {
+	batch := &pgx.Batch{}
+	var applied []*model.BatchItemResult
+	for i := range result.Items {
+		item := &result.Items[i]
+		if _, ok := replayed[i]; ok || item.Status != model.BatchItemSucceeded {
+			continue
+		}
+		var idempotencyKey *string
+		if key := request.ItemIdempotencyKey(i); key != "" {
+			idempotencyKey = &key
+		}
+		record := item.Transaction
+		sql, args, err := Builder().Insert("transactions").
+			Columns("uuid", "wallet_uuid", "transaction_type", "amount", "currency", "balance_after", "idempotency_key").
+			Values(record.UUID, record.WalletID, record.OperationType, record.Amount, record.Currency, record.BalanceAfter, idempotencyKey).
+			Suffix("RETURNING " + strings.Join(transactionColumns, ", ")).ToSql()
+		if err != nil {
+			r.logger(ctx).Errorf("Failed to build insert query for writeBatch: %v", err)
+			return err
+		}
+		batch.Queue(sql, args...)
+
+		sql, args, err = postingInsert(model.CashPosting(*record)).ToSql()
+		if err != nil {
+			r.logger(ctx).Errorf("Failed to build posting query for writeBatch: %v", err)
+			return err
+		}
+		batch.Queue(sql, args...)
+		applied = append(applied, item)
+	}
+
+	var updated []uuid.UUID
+	for _, UUID := range walletIDs {
+		wallet, ok := wallets[UUID]
+		if !ok || wallet.delta.IsZero() {
+			continue
+		}
+		sql, args, err := Builder().Update("wallets").
+			Set("balance", squirrel.Expr("balance + ?", wallet.delta)).
+			Where(squirrel.Eq{"uuid": UUID}).
+			Suffix("RETURNING balance").ToSql()
+		if err != nil {
+			r.logger(ctx).Errorf("Failed to build update query for writeBatch: %v", err)
+			return err
+		}
+		batch.Queue(sql, args...)
+		updated = append(updated, UUID)
+	}
+
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	defer results.Close()
+
+	for _, item := range applied {
+		record, err := scanTransactionRecord(results.QueryRow())
+		if err != nil {
+			r.logger(ctx).WithField(logger.FieldWalletUUID, item.Transaction.WalletID).Errorf("Error saving batch transaction: %v", err)
+			return err
+		}
+		if _, err = results.Exec(); err != nil {
+			r.logger(ctx).Errorf("Error saving ledger posting %s: %v", record.UUID, err)
+			return err
+		}
+		item.Transaction = &record
+	}
+	for _, UUID := range updated {
+		var balance decimal.Decimal
+		if err := results.QueryRow().Scan(&balance); err != nil {
+			r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Errorf("Error updating batch wallet: %v", err)
+			return err
+		}
+		// Кошелёк заблокирован с начала пакета, поэтому расхождение означает ошибку в проверке.
+		if !balance.Equal(wallets[UUID].balance) {
+			return fmt.Errorf("batch balance of wallet %s is %s, expected %s", UUID, balance, wallets[UUID].balance)
+		}
+	}
+	return results.Close()
+}
@@ -0,0 +1,242 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/repository/postgresql/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockRows возвращает по строке на каждую функцию scan.
+func mockRows(ctrl *gomock.Controller, scans ...func(dest ...any) error) *mock.MockRows {
+	rows := mock.NewMockRows(ctrl)
+	calls := make([]*gomock.Call, 0, len(scans)+1)
+	for _, scan := range scans {
+		calls = append(calls, rows.EXPECT().Next().Return(true), rows.EXPECT().Scan(gomock.Any()).DoAndReturn(scan))
+	}
+	calls = append(calls, rows.EXPECT().Next().Return(false))
+	gomock.InOrder(calls...)
+	rows.EXPECT().Err().Return(nil).AnyTimes()
+	rows.EXPECT().Close().AnyTimes()
+	return rows
+}
+
+func TestWalletRepo_ProcessBatch_BestEffortSkipsFailedItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	loadResults := mock.NewMockBatchResults(ctrl)
+	writeResults := mock.NewMockBatchResults(ctrl)
+	recordRow := mock.NewMockRow(ctrl)
+	balanceRow := mock.NewMockRow(ctrl)
+
+	walletUUID := uuid.New()
+	request := model.BatchRequest{Mode: model.BatchBestEffort, Transactions: []model.Transaction{
+		{WalletID: walletUUID, OperationType: model.Withdraw, Amount: decimal.NewFromInt32(30)},
+		{WalletID: walletUUID, OperationType: model.Withdraw, Amount: decimal.NewFromInt32(100)},
+	}}
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	gomock.InOrder(
+		mockTx.EXPECT().SendBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, b *pgx.Batch) pgx.BatchResults {
+			assert.Equal(t, 3, b.Len())
+			return loadResults
+		}),
+		mockTx.EXPECT().SendBatch(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, b *pgx.Batch) pgx.BatchResults {
+			// Строка и проводка принятой операции и один UPDATE кошелька.
+			assert.Equal(t, 3, b.Len())
+			return writeResults
+		}),
+	)
+
+	gomock.InOrder(
+		loadResults.EXPECT().Query().Return(mockRows(ctrl, func(dest ...any) error {
+			*dest[0].(*uuid.UUID) = walletUUID
+			*dest[1].(*decimal.Decimal) = decimal.NewFromInt32(100)
+			*dest[2].(*decimal.Decimal) = decimal.Zero
+			*dest[3].(*string) = model.DefaultCurrency
+			*dest[4].(*model.WalletStatus) = model.WalletActive
+			return nil
+		}), nil),
+		loadResults.EXPECT().Query().Return(mockRows(ctrl), nil),
+		loadResults.EXPECT().Query().Return(mockRows(ctrl), nil),
+	)
+	loadResults.EXPECT().Close().Return(nil).AnyTimes()
+
+	gomock.InOrder(
+		writeResults.EXPECT().QueryRow().Return(recordRow),
+		writeResults.EXPECT().Exec().Return(pgconn.CommandTag{}, nil),
+		writeResults.EXPECT().QueryRow().Return(balanceRow),
+	)
+	writeResults.EXPECT().Close().Return(nil).AnyTimes()
+	recordRow.EXPECT().Scan(gomock.Any()).Return(nil)
+	balanceRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*decimal.Decimal) = decimal.NewFromInt32(70)
+		return nil
+	})
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	result, err := repo.ProcessBatch(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, model.BatchItemSucceeded, result.Items[0].Status)
+	assert.Equal(t, model.BatchItemFailed, result.Items[1].Status)
+	assert.ErrorIs(t, result.Items[1].Err, model.ErrInsufficientFunds)
+}
+
+func TestWalletRepo_ProcessBatch_AtomicRollsBackOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	loadResults := mock.NewMockBatchResults(ctrl)
+
+	known, missing := uuid.New(), uuid.New()
+	request := model.BatchRequest{Transactions: []model.Transaction{
+		{WalletID: known, OperationType: model.Deposit, Amount: decimal.NewFromInt32(30)},
+		{WalletID: missing, OperationType: model.Deposit, Amount: decimal.NewFromInt32(30)},
+	}}
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().SendBatch(gomock.Any(), gomock.Any()).Return(loadResults).Times(1)
+	gomock.InOrder(
+		loadResults.EXPECT().Query().Return(mockRows(ctrl, func(dest ...any) error {
+			*dest[0].(*uuid.UUID) = known
+			*dest[3].(*string) = model.DefaultCurrency
+			*dest[4].(*model.WalletStatus) = model.WalletActive
+			return nil
+		}), nil),
+		loadResults.EXPECT().Query().Return(mockRows(ctrl), nil),
+		loadResults.EXPECT().Query().Return(mockRows(ctrl), nil),
+	)
+	loadResults.EXPECT().Close().Return(nil).AnyTimes()
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(gomock.Any()).Times(0)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	result, err := repo.ProcessBatch(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.BatchItemRolledBack, result.Items[0].Status)
+	assert.Nil(t, result.Items[0].Transaction)
+	assert.Equal(t, model.BatchItemFailed, result.Items[1].Status)
+	assert.ErrorIs(t, result.Items[1].Err, model.ErrWalletNotFound)
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, 1, result.Failed)
+}
+
+func TestWalletRepo_ProcessBatch_ReplaysStoredItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	loadResults := mock.NewMockBatchResults(ctrl)
+
+	walletUUID, stored := uuid.New(), uuid.New()
+	request := model.BatchRequest{IdempotencyKey: "batch-1", Transactions: []model.Transaction{
+		{WalletID: walletUUID, OperationType: model.Deposit, Amount: decimal.NewFromInt32(30)},
+	}}
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	// Пакет уже записан: повтор ничего не пишет и отдаёт сохранённую операцию.
+	mockTx.EXPECT().SendBatch(gomock.Any(), gomock.Any()).Return(loadResults).Times(1)
+	gomock.InOrder(
+		loadResults.EXPECT().Query().Return(mockRows(ctrl, func(dest ...any) error {
+			*dest[0].(*uuid.UUID) = walletUUID
+			*dest[3].(*string) = model.DefaultCurrency
+			*dest[4].(*model.WalletStatus) = model.WalletActive
+			return nil
+		}), nil),
+		loadResults.EXPECT().Query().Return(mockRows(ctrl), nil),
+		loadResults.EXPECT().Query().Return(mockRows(ctrl), nil),
+	)
+	loadResults.EXPECT().Close().Return(nil).AnyTimes()
+	mockTx.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+			assert.Contains(t, args, "batch-1:0")
+			return mockRows(ctrl, func(dest ...any) error {
+				*dest[0].(*string) = "batch-1:0"
+				*dest[1].(*uuid.UUID) = stored
+				*dest[2].(*uuid.UUID) = walletUUID
+				*dest[3].(*model.OperationType) = model.Deposit
+				*dest[4].(*decimal.Decimal) = decimal.NewFromInt32(30)
+				*dest[5].(*string) = model.DefaultCurrency
+				return nil
+			}), nil
+		})
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	result, err := repo.ProcessBatch(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, stored, result.Items[0].Transaction.UUID)
+}
+
+func TestWalletRepo_ProcessBatch_IdempotencyKeyConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	loadResults := mock.NewMockBatchResults(ctrl)
+
+	walletUUID := uuid.New()
+	request := model.BatchRequest{IdempotencyKey: "batch-1", Transactions: []model.Transaction{
+		{WalletID: walletUUID, OperationType: model.Deposit, Amount: decimal.NewFromInt32(30)},
+	}}
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().SendBatch(gomock.Any(), gomock.Any()).Return(loadResults).Times(1)
+	gomock.InOrder(
+		loadResults.EXPECT().Query().Return(mockRows(ctrl, func(dest ...any) error {
+			*dest[0].(*uuid.UUID) = walletUUID
+			*dest[3].(*string) = model.DefaultCurrency
+			*dest[4].(*model.WalletStatus) = model.WalletActive
+			return nil
+		}), nil),
+		loadResults.EXPECT().Query().Return(mockRows(ctrl), nil),
+		loadResults.EXPECT().Query().Return(mockRows(ctrl), nil),
+	)
+	loadResults.EXPECT().Close().Return(nil).AnyTimes()
+	// Под ключом записана операция с другой суммой; остаток строк уже не читается.
+	storedRows := mock.NewMockRows(ctrl)
+	storedRows.EXPECT().Next().Return(true)
+	storedRows.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "batch-1:0"
+		*dest[2].(*uuid.UUID) = walletUUID
+		*dest[3].(*model.OperationType) = model.Deposit
+		*dest[4].(*decimal.Decimal) = decimal.NewFromInt32(50)
+		*dest[5].(*string) = model.DefaultCurrency
+		return nil
+	})
+	storedRows.EXPECT().Close()
+	mockTx.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(storedRows, nil)
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+	mockTx.EXPECT().Commit(gomock.Any()).Times(0)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	_, err := repo.ProcessBatch(context.Background(), request)
+
+	assert.ErrorIs(t, err, model.ErrIdempotencyKeyConflict)
+}
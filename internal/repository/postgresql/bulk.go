@@ -0,0 +1,213 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
+	"github.com/jackc/pgx/v5"
+)
+
+// walletImportTable — временная таблица, в которую COPY загружает строки импорта; она живёт
+// до конца транзакции, и из неё одним запросом на таблицу переносятся кошельки и их остатки.
+const walletImportTable = `CREATE TEMP TABLE wallet_import (
+	line BIGINT NOT NULL,
+	uuid UUID NOT NULL,
+	owner_id VARCHAR(255),
+	balance DECIMAL(38, 18) NOT NULL,
+	currency VARCHAR(10) NOT NULL,
+	transaction_uuid UUID NOT NULL DEFAULT gen_random_uuid()
+) ON COMMIT DROP`
+
+var walletImportColumns = []string{"line", "uuid", "owner_id", "balance", "currency"}
+
+// importRows подаёт проверенные строки импорта в COPY.
+type importRows struct {
+	source model.WalletImportSource
+}
+
+func (s importRows) Next() bool {
+	return s.source.Next()
+}
+
+func (s importRows) Values() ([]any, error) {
+	row := s.source.Row()
+	return []any{row.Line, row.Wallet.UUID, row.Wallet.OwnerID, row.Wallet.Balance, row.Wallet.CurrencyCode()}, nil
+}
+
+func (s importRows) Err() error {
+	return s.source.Err()
+}
+
+// ImportWallets загружает кошельки одной транзакцией: строки идут через COPY во временную
+// таблицу, после чего переносятся в wallets. Ненулевой начальный баланс, как и в CreateWallet,
+// фиксируется депозитом, но в книге проводится против входящих остатков (system:opening).
+// Если источник нашёл некорректные строки или UUID уже заняты, не записывается ничего.
+func (r *WalletRepo) ImportWallets(ctx context.Context, source model.WalletImportSource) (imported int64, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.ImportWallets")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to begin transaction: %v", err)
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			r.rollback(ctx, tx)
+			r.logger(ctx).Warnf("Transaction rolled back due to error: %v", err)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, walletImportTable); err != nil {
+		r.logger(ctx).Errorf("Failed to create wallet import table: %v", err)
+		return 0, err
+	}
+
+	imported, err = tx.CopyFrom(ctx, pgx.Identifier{"wallet_import"}, walletImportColumns, importRows{source: source})
+	// Ошибка источника прерывает COPY; сервер при этом сообщает лишь об отмене, поэтому
+	// возвращается исходная ошибка со списком строк.
+	if sourceErr := source.Err(); sourceErr != nil {
+		err = sourceErr
+		return 0, err
+	}
+	if err != nil {
+		r.logger(ctx).Errorf("Error copying wallets into the import table: %v", err)
+		return 0, err
+	}
+
+	if err = r.importConflicts(ctx, tx); err != nil {
+		return 0, err
+	}
+
+	for _, query := range importQueries() {
+		var sql string
+		var args []any
+		if sql, args, err = query.ToSql(); err != nil {
+			r.logger(ctx).Errorf("Failed to build query for ImportWallets: %v", err)
+			return 0, err
+		}
+		if _, err = tx.Exec(ctx, sql, args...); err != nil {
+			r.logger(ctx).Errorf("Error moving imported wallets: %v", err)
+			return 0, err
+		}
+	}
+
+	if err = r.commit(ctx, tx); err != nil {
+		r.logger(ctx).Errorf("Failed to commit transaction: %v", err)
+		return 0, err
+	}
+	return imported, nil
+}
+
+// importConflicts отклоняет строки, UUID которых уже заняты существующими кошельками.
+func (r *WalletRepo) importConflicts(ctx context.Context, tx pgx.Tx) error {
+	sql, args, err := Builder().Select("i.line", "i.uuid").
+		From("wallet_import i").
+		Join("wallets w ON w.uuid = i.uuid").
+		OrderBy("i.line").
+		Limit(model.MaxImportErrors + 1).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for importConflicts: %v", err)
+		return err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		r.logger(ctx).Errorf("Error checking imported wallets for conflicts: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	conflicts := &model.WalletImportError{}
+	for rows.Next() {
+		var line model.ImportLineError
+		var UUID string
+		if err = rows.Scan(&line.Line, &UUID); err != nil {
+			r.logger(ctx).Errorf("Error scanning import conflict: %v", err)
+			return err
+		}
+		if len(conflicts.Errors) == model.MaxImportErrors {
+			conflicts.Truncated = true
+			break
+		}
+		line.Message = fmt.Sprintf("wallet %s already exists", UUID)
+		conflicts.Errors = append(conflicts.Errors, line)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger(ctx).Errorf("Error reading import conflicts: %v", err)
+		return err
+	}
+
+	if len(conflicts.Errors) > 0 {
+		return conflicts
+	}
+	return nil
+}
+
+// importQueries переносит строки из временной таблицы: кошельки, депозиты начальных
+// балансов и обе стороны их проводок. Баланс каждой проводки проверяется триггером при COMMIT.
+func importQueries() []squirrel.Sqlizer {
+	funded := squirrel.Gt{"balance": 0}
+	return []squirrel.Sqlizer{
+		Builder().Insert("wallets").
+			Columns("uuid", "owner_id", "balance", "currency").
+			Select(Builder().Select("uuid", "owner_id", "balance", "currency").From("wallet_import")),
+		Builder().Insert("transactions").
+			Columns("uuid", "wallet_uuid", "transaction_type", "amount", "currency", "balance_after").
+			Select(Builder().Select("transaction_uuid", "uuid").
+				Column(squirrel.Expr("?::text", model.Deposit)).
+				Columns("balance", "currency", "balance").
+				From("wallet_import").Where(funded)),
+		Builder().Insert("ledger_entries").
+			Columns("posting_uuid", "transaction_uuid", "account", "side", "amount", "currency").
+			Select(Builder().Select("transaction_uuid", "transaction_uuid", "'wallet:' || uuid").
+				Column(squirrel.Expr("?::text", model.Credit)).
+				Columns("balance", "currency").
+				From("wallet_import").Where(funded)),
+		Builder().Insert("ledger_entries").
+			Columns("posting_uuid", "account", "side", "amount", "currency").
+			Select(Builder().Select("transaction_uuid").
+				Column(squirrel.Expr("?::text", model.OpeningAccount)).
+				Column(squirrel.Expr("?::text", model.Debit)).
+				Columns("balance", "currency").
+				From("wallet_import").Where(funded)),
+	}
+}
+
+// ExportWallets выгружает таблицу кошельков в w через COPY TO STDOUT, не собирая её в памяти.
+func (r *WalletRepo) ExportWallets(ctx context.Context, format model.BulkFormat, w io.Writer) (exported int64, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.ExportWallets")
+	defer func() { tracing.End(span, err) }()
+
+	conn, err := r.PgxPool.Acquire(ctx)
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to acquire connection for ExportWallets: %v", err)
+		return 0, err
+	}
+	defer conn.Release()
+
+	tag, err := conn.Conn().PgConn().CopyTo(ctx, w, walletExportQuery(format))
+	if err != nil {
+		r.logger(ctx).Errorf("Error exporting wallets: %v", err)
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// walletExportQuery выгружает те же поля, что и GET /api/v1/wallets/{uuid}, кроме доступного
+// баланса. Для NDJSON используется CSV-формат COPY с управляющими символами в роли разделителя
+// и кавычки: в JSON они всегда экранированы, поэтому строки выходят без изменений, тогда как
+// текстовый формат COPY удвоил бы обратные слэши.
+func walletExportQuery(format model.BulkFormat) string {
+	if format == model.BulkNDJSON {
+		return `COPY (SELECT json_build_object('uuid', uuid, 'ownerId', owner_id, 'balance', trim_scale(balance)::text,
+		'currency', currency, 'status', status, 'created_at', created_at) FROM wallets ORDER BY uuid)
+		TO STDOUT WITH (FORMAT csv, DELIMITER E'\x01', QUOTE E'\x02')`
+	}
+	return `COPY (SELECT uuid, owner_id, trim_scale(balance) AS balance, currency, status, created_at FROM wallets ORDER BY uuid)
+		TO STDOUT WITH (FORMAT csv, HEADER true)`
+}
@@ -0,0 +1,134 @@
+package postgresql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/repository/postgresql/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// importSource — источник импорта из готовых строк.
+type importSource struct {
+	rows []model.WalletImportRow
+	next int
+	err  error
+}
+
+func (s *importSource) Next() bool {
+	s.next++
+	return s.next <= len(s.rows)
+}
+
+func (s *importSource) Row() model.WalletImportRow {
+	return s.rows[s.next-1]
+}
+
+func (s *importSource) Err() error {
+	return s.err
+}
+
+func TestWalletRepo_ImportWallets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	source := &importSource{rows: []model.WalletImportRow{
+		{Line: 2, Wallet: model.WalletCreation{UUID: uuid.New(), Balance: decimal.NewFromInt32(10), Currency: "RUB"}},
+		{Line: 3, Wallet: model.WalletCreation{UUID: uuid.New()}},
+	}}
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	gomock.InOrder(
+		mockTx.EXPECT().Exec(gomock.Any(), walletImportTable).Return(pgconn.CommandTag{}, nil),
+		mockTx.EXPECT().CopyFrom(gomock.Any(), pgx.Identifier{"wallet_import"}, walletImportColumns, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ pgx.Identifier, _ []string, rows pgx.CopyFromSource) (int64, error) {
+				var copied int64
+				for rows.Next() {
+					values, err := rows.Values()
+					assert.NoError(t, err)
+					// Пустая валюта сохраняется как валюта по умолчанию.
+					assert.Equal(t, model.DefaultCurrency, values[4])
+					copied++
+				}
+				return copied, rows.Err()
+			}),
+		mockTx.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRows(ctrl), nil),
+		mockTx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(pgconn.CommandTag{}, nil).Times(4),
+		mockTx.EXPECT().Commit(gomock.Any()).Return(nil),
+	)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+	imported, err := repo.ImportWallets(context.Background(), source)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), imported)
+}
+
+func TestWalletRepo_ImportWallets_ExistingWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	existing := uuid.New()
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().Exec(gomock.Any(), walletImportTable).Return(pgconn.CommandTag{}, nil)
+	mockTx.EXPECT().CopyFrom(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	mockTx.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRows(ctrl, func(dest ...any) error {
+		*dest[0].(*int64) = 7
+		*dest[1].(*string) = existing.String()
+		return nil
+	}), nil)
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+	_, err := repo.ImportWallets(context.Background(), &importSource{})
+
+	var invalid *model.WalletImportError
+	assert.ErrorAs(t, err, &invalid)
+	assert.Equal(t, []model.ImportLineError{{Line: 7, Message: "wallet " + existing.String() + " already exists"}}, invalid.Errors)
+}
+
+func TestWalletRepo_ImportWallets_SourceErrorWins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	invalid := &model.WalletImportError{Errors: []model.ImportLineError{{Line: 4, Message: "invalid balance"}}}
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().Exec(gomock.Any(), walletImportTable).Return(pgconn.CommandTag{}, nil)
+	mockTx.EXPECT().CopyFrom(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(int64(0), &pgconn.PgError{Code: "57014", Message: "COPY from stdin failed"})
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+	_, err := repo.ImportWallets(context.Background(), &importSource{err: invalid})
+
+	assert.Same(t, invalid, err)
+}
+
+func TestImportQueries_SQL(t *testing.T) {
+	var statements []string
+	for _, query := range importQueries() {
+		sql, _, err := query.ToSql()
+		assert.NoError(t, err)
+		statements = append(statements, sql)
+	}
+
+	assert.Equal(t, "INSERT INTO transactions (uuid,wallet_uuid,transaction_type,amount,currency,balance_after) "+
+		"SELECT transaction_uuid, uuid, $1::text, balance, currency, balance FROM wallet_import WHERE balance > $2", statements[1])
+	assert.True(t, strings.HasPrefix(statements[3], "INSERT INTO ledger_entries (posting_uuid,account,side,amount,currency) SELECT transaction_uuid, $1::text, $2::text"))
+}
@@ -1,6 +1,7 @@
 package postgresql
 
 import (
+	"errors"
 	"fmt"
 	"os"
 )
@@ -25,7 +26,7 @@ func NewConfig() (*Config, error) {
 	database := os.Getenv("POSTGRES_DB")
 
 	if username == "" || password == "" || host == "" || port == "" || database == "" {
-		return nil, fmt.Errorf("отсутствует необходимая конфигурация базы данных")
+		return nil, errors.New("missing required database configuration")
 	}
 
 	return &Config{
@@ -0,0 +1,58 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/sirupsen/logrus"
+)
+
+type HealthRepo struct {
+	PgxPool
+	log *logrus.Logger
+}
+
+func NewHealthRepo(postgresql PgxPool, log *logrus.Logger) HealthRepo {
+	return HealthRepo{PgxPool: postgresql, log: log}
+}
+
+func (r *HealthRepo) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, r.log)
+}
+
+// MigrationVersion читает состояние из таблицы schema_migrations, которую ведёт golang-migrate.
+func (r *HealthRepo) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	sql, args, err := Builder().Select("version", "dirty").
+		From("schema_migrations").
+		Limit(1).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for MigrationVersion: %v", err)
+		return 0, false, err
+	}
+
+	var (
+		version int64
+		dirty   bool
+	)
+	if err = r.PgxPool.QueryRow(ctx, sql, args...).Scan(&version, &dirty); err != nil {
+		r.logger(ctx).Errorf("Error reading migration version: %v", err)
+		return 0, false, err
+	}
+
+	return uint(version), dirty, nil
+}
+
+func (r *HealthRepo) PoolStats() model.PoolStats {
+	stat := r.PgxPool.Stat()
+	stats := model.PoolStats{
+		AcquiredConns: stat.AcquiredConns(),
+		IdleConns:     stat.IdleConns(),
+		TotalConns:    stat.TotalConns(),
+		MaxConns:      stat.MaxConns(),
+	}
+	if stats.MaxConns > 0 {
+		stats.Saturation = float64(stats.AcquiredConns) / float64(stats.MaxConns)
+	}
+	return stats
+}
@@ -0,0 +1,312 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+var holdColumns = []string{"uuid", "wallet_uuid", "amount", "captured_amount", "currency", "status",
+	"transaction_uuid", "expires_at", "created_at"}
+
+// expireHoldsSQL одним запросом помечает истёкшие холды и снимает их суммы с кошельков.
+// Холды блокируются раньше кошельков, как и при списании, а занятые строки пропускаются.
+const expireHoldsSQL = `
+WITH expired AS (
+    UPDATE holds SET status = 'EXPIRED', updated_at = CURRENT_TIMESTAMP
+    WHERE uuid IN (
+        SELECT uuid FROM holds
+        WHERE status = 'ACTIVE' AND expires_at <= CURRENT_TIMESTAMP
+        ORDER BY expires_at
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    )
+    RETURNING wallet_uuid, amount
+), released AS (
+    UPDATE wallets w SET held = w.held - t.amount
+    FROM (SELECT wallet_uuid, SUM(amount) AS amount FROM expired GROUP BY wallet_uuid) t
+    WHERE w.uuid = t.wallet_uuid
+)
+SELECT COUNT(*) FROM expired`
+
+func scanHold(row pgx.Row, extra ...any) (model.Hold, error) {
+	var hold model.Hold
+	dest := []any{&hold.UUID, &hold.WalletID, &hold.Amount, &hold.CapturedAmount, &hold.Currency, &hold.Status,
+		&hold.TransactionID, &hold.ExpiresAt, &hold.CreatedAt}
+	err := row.Scan(append(dest, extra...)...)
+	return hold, err
+}
+
+// CreateHold увеличивает зарезервированную сумму кошелька тем же условным UPDATE, что и
+// списание: резерв возможен, только если доступного баланса хватает.
+func (r *WalletRepo) CreateHold(ctx context.Context, request model.HoldRequest) (model.Hold, error) {
+	log := r.logger(ctx).WithField(logger.FieldWalletUUID, request.WalletID)
+
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Failed to begin transaction: %v", err)
+		return model.Hold{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			r.rollback(ctx, tx)
+			log.Warnf("Transaction rolled back due to error: %v", err)
+		}
+	}()
+
+	sql, args, err := Builder().Update("wallets").
+		Set("held", squirrel.Expr("held + ?", request.Amount)).
+		Where(squirrel.Eq{
+			"uuid":     request.WalletID,
+			"currency": request.CurrencyCode(),
+			"status":   model.StatusesAllowing(model.Withdraw),
+		}).
+		Where(squirrel.Expr("balance - held >= ?", request.Amount)).ToSql()
+	if err != nil {
+		log.Errorf("Failed to build query for CreateHold: %v", err)
+		return model.Hold{}, err
+	}
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		log.Errorf("Error reserving funds: %v", err)
+		return model.Hold{}, err
+	}
+	if tag.RowsAffected() == 0 {
+		err = r.explainMissedUpdate(ctx, request.WalletID, request.CurrencyCode(), model.Withdraw, tx)
+		return model.Hold{}, err
+	}
+
+	sql, args, err = Builder().Insert("holds").
+		Columns("wallet_uuid", "amount", "currency", "status", "expires_at").
+		Values(request.WalletID, request.Amount, request.CurrencyCode(), model.HoldActive,
+			squirrel.Expr("CURRENT_TIMESTAMP + make_interval(secs => ?)", request.TTL().Seconds())).
+		Suffix("RETURNING " + strings.Join(holdColumns, ", ")).ToSql()
+	if err != nil {
+		log.Errorf("Failed to build insert query for CreateHold: %v", err)
+		return model.Hold{}, err
+	}
+	hold, err := scanHold(tx.QueryRow(ctx, sql, args...))
+	if err != nil {
+		log.Errorf("Error saving hold: %v", err)
+		return model.Hold{}, err
+	}
+
+	if err = r.commit(ctx, tx); err != nil {
+		log.Errorf("Failed to commit transaction: %v", err)
+		return model.Hold{}, err
+	}
+	return hold, nil
+}
+
+func (r *WalletRepo) GetHold(ctx context.Context, UUID uuid.UUID) (model.Hold, error) {
+	sql, args, err := Builder().Select(holdColumns...).
+		From("holds").
+		Where(squirrel.Eq{"uuid": UUID}).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for GetHold: %v", err)
+		return model.Hold{}, err
+	}
+
+	hold, err := scanHold(r.PgxPool.QueryRow(ctx, sql, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Hold{}, model.ErrHoldNotFound
+	}
+	if err != nil {
+		r.logger(ctx).Errorf("Error executing query for GetHold with hold %s: %v", UUID, err)
+		return model.Hold{}, err
+	}
+	return hold, nil
+}
+
+// CaptureHold превращает холд в списание amount и освобождает весь зарезервированный остаток.
+func (r *WalletRepo) CaptureHold(ctx context.Context, UUID uuid.UUID, amount decimal.Decimal) (result model.HoldCaptureResult, err error) {
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to begin transaction: %v", err)
+		return model.HoldCaptureResult{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			r.rollback(ctx, tx)
+			r.logger(ctx).Warnf("Transaction rolled back due to error: %v", err)
+		}
+	}()
+
+	hold, err := r.lockActiveHold(ctx, UUID, tx)
+	if err != nil {
+		return model.HoldCaptureResult{}, err
+	}
+	if amount.GreaterThan(hold.Amount) {
+		return model.HoldCaptureResult{}, model.ErrCaptureExceedsHold
+	}
+
+	// Инвариант held <= balance гарантирует средства; строку может не найти только
+	// статус кошелька, запрещающий списания.
+	sql, args, err := Builder().Update("wallets").
+		Set("balance", squirrel.Expr("balance - ?", amount)).
+		Set("held", squirrel.Expr("held - ?", hold.Amount)).
+		Where(squirrel.Eq{"uuid": hold.WalletID, "status": model.StatusesAllowing(model.Withdraw)}).
+		Suffix("RETURNING balance").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for CaptureHold: %v", err)
+		return model.HoldCaptureResult{}, err
+	}
+	var balance decimal.Decimal
+	err = tx.QueryRow(ctx, sql, args...).Scan(&balance)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = r.explainMissedUpdate(ctx, hold.WalletID, hold.Currency, model.Withdraw, tx)
+		return model.HoldCaptureResult{}, err
+	}
+	if err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, hold.WalletID).Errorf("Error capturing hold %s: %v", UUID, err)
+		return model.HoldCaptureResult{}, err
+	}
+
+	withdrawal := model.Transaction{
+		WalletID:      hold.WalletID,
+		OperationType: model.Withdraw,
+		Amount:        amount,
+		Currency:      hold.Currency,
+	}
+	if err = r.enforceLimits(ctx, withdrawal, balance, tx); err != nil {
+		return model.HoldCaptureResult{}, err
+	}
+
+	record, err := r.SaveTransaction(ctx, withdrawal, balance, tx)
+	if err != nil {
+		return model.HoldCaptureResult{}, err
+	}
+	if err = r.SavePosting(ctx, model.CashPosting(record), tx); err != nil {
+		return model.HoldCaptureResult{}, err
+	}
+
+	hold, err = r.finishHold(ctx, UUID, model.HoldCaptured, tx, map[string]interface{}{
+		"captured_amount":  amount,
+		"transaction_uuid": record.UUID,
+	})
+	if err != nil {
+		return model.HoldCaptureResult{}, err
+	}
+
+	if err = r.commit(ctx, tx); err != nil {
+		r.logger(ctx).Errorf("Failed to commit transaction: %v", err)
+		return model.HoldCaptureResult{}, err
+	}
+	return model.HoldCaptureResult{Hold: hold, Transaction: record}, nil
+}
+
+func (r *WalletRepo) ReleaseHold(ctx context.Context, UUID uuid.UUID) (hold model.Hold, err error) {
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to begin transaction: %v", err)
+		return model.Hold{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			r.rollback(ctx, tx)
+			r.logger(ctx).Warnf("Transaction rolled back due to error: %v", err)
+		}
+	}()
+
+	hold, err = r.lockActiveHold(ctx, UUID, tx)
+	if err != nil {
+		return model.Hold{}, err
+	}
+
+	sql, args, err := Builder().Update("wallets").
+		Set("held", squirrel.Expr("held - ?", hold.Amount)).
+		Where(squirrel.Eq{"uuid": hold.WalletID}).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for ReleaseHold: %v", err)
+		return model.Hold{}, err
+	}
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, hold.WalletID).Errorf("Error releasing hold %s: %v", UUID, err)
+		return model.Hold{}, err
+	}
+
+	hold, err = r.finishHold(ctx, UUID, model.HoldReleased, tx, nil)
+	if err != nil {
+		return model.Hold{}, err
+	}
+
+	if err = r.commit(ctx, tx); err != nil {
+		r.logger(ctx).Errorf("Failed to commit transaction: %v", err)
+		return model.Hold{}, err
+	}
+	return hold, nil
+}
+
+// ExpireHolds закрывает не больше limit истёкших холдов и возвращает их число.
+func (r *WalletRepo) ExpireHolds(ctx context.Context, limit int) (int64, error) {
+	var expired int64
+	if err := r.PgxPool.QueryRow(ctx, expireHoldsSQL, limit).Scan(&expired); err != nil {
+		r.logger(ctx).Errorf("Error expiring holds: %v", err)
+		return 0, err
+	}
+	return expired, nil
+}
+
+// lockActiveHold блокирует строку холда до строки кошелька — в том же порядке, что и ExpireHolds.
+// Истёкший, но ещё не обработанный воркером холд списать уже нельзя.
+func (r *WalletRepo) lockActiveHold(ctx context.Context, UUID uuid.UUID, tx pgx.Tx) (model.Hold, error) {
+	sql, args, err := Builder().Select(holdColumns...).
+		Column("expires_at <= CURRENT_TIMESTAMP").
+		From("holds").
+		Where(squirrel.Eq{"uuid": UUID}).
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for lockActiveHold: %v", err)
+		return model.Hold{}, err
+	}
+
+	var expired bool
+	hold, err := scanHold(tx.QueryRow(ctx, sql, args...), &expired)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Hold{}, model.ErrHoldNotFound
+	}
+	if err != nil {
+		r.logger(ctx).Errorf("Error locking hold %s: %v", UUID, err)
+		return model.Hold{}, err
+	}
+	if hold.Status != model.HoldActive || expired {
+		r.logger(ctx).WithFields(logrus.Fields{
+			logger.FieldWalletUUID: hold.WalletID,
+			"hold_uuid":            UUID,
+			"hold_status":          hold.Status,
+		}).Warn("Hold is no longer active")
+		return model.Hold{}, model.ErrHoldNotActive
+	}
+	return hold, nil
+}
+
+func (r *WalletRepo) finishHold(ctx context.Context, UUID uuid.UUID, status model.HoldStatus, tx pgx.Tx, set map[string]interface{}) (model.Hold, error) {
+	sql, args, err := Builder().Update("holds").
+		Set("status", status).
+		Set("updated_at", squirrel.Expr("CURRENT_TIMESTAMP")).
+		SetMap(set).
+		Where(squirrel.Eq{"uuid": UUID}).
+		Suffix("RETURNING " + strings.Join(holdColumns, ", ")).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for finishHold: %v", err)
+		return model.Hold{}, err
+	}
+
+	hold, err := scanHold(tx.QueryRow(ctx, sql, args...))
+	if err != nil {
+		r.logger(ctx).Errorf("Error updating hold %s: %v", UUID, err)
+		return model.Hold{}, err
+	}
+	return hold, nil
+}
@@ -0,0 +1,142 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+var limitColumns = []string{"max_operation", "max_balance", "daily_deposit", "daily_withdrawal",
+	"monthly_deposit", "monthly_withdrawal"}
+
+func limitDest(limits *model.Limits) []any {
+	return []any{&limits.MaxOperation, &limits.MaxBalance, &limits.DailyDeposit, &limits.DailyWithdrawal,
+		&limits.MonthlyDeposit, &limits.MonthlyWithdrawal}
+}
+
+// enforceLimits проверяет операцию после изменения баланса в tx. Строка кошелька к этому
+// моменту заблокирована, поэтому суммы за окна не меняются до фиксации или отката.
+func (r *WalletRepo) enforceLimits(ctx context.Context, transaction model.Transaction, balance decimal.Decimal, tx pgx.Tx) error {
+	own, err := r.walletLimits(ctx, transaction.WalletID, tx)
+	if err != nil {
+		return err
+	}
+	limits := r.DefaultLimits.For(transaction.CurrencyCode()).Override(own)
+
+	credit := transaction.OperationType.IsCredit()
+	usage := model.LimitUsage{Credit: credit, Reversal: transaction.ReversalOf != nil, Amount: transaction.Amount, Balance: balance}
+	if !usage.Reversal && limits.NeedsTotals(credit) {
+		daily, monthly, err := r.rollingTotals(ctx, transaction.WalletID, credit, tx)
+		if err != nil {
+			return err
+		}
+		usage.Daily = daily.Add(transaction.Amount)
+		usage.Monthly = monthly.Add(transaction.Amount)
+	}
+
+	if err = limits.Check(usage); err != nil {
+		r.logger(ctx).WithFields(logrus.Fields{
+			logger.FieldWalletUUID: transaction.WalletID,
+			logger.FieldOperation:  transaction.OperationType,
+		}).Warnf("Operation rejected: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *WalletRepo) walletLimits(ctx context.Context, UUID uuid.UUID, q querier) (model.Limits, error) {
+	sql, args, err := Builder().Select(limitColumns...).
+		From("wallet_limits").
+		Where(squirrel.Eq{"wallet_uuid": UUID}).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for walletLimits: %v", err)
+		return model.Limits{}, err
+	}
+
+	var limits model.Limits
+	err = q.QueryRow(ctx, sql, args...).Scan(limitDest(&limits)...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Limits{}, nil
+	}
+	if err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Errorf("Error reading wallet limits: %v", err)
+		return model.Limits{}, err
+	}
+	return limits, nil
+}
+
+// velocityAmount — сумма операции за вычетом её отмен. Отмены в суммы за окна не входят,
+// а уменьшают отменённую операцию, если та сама попала в окно.
+const velocityAmount = "t.amount - (SELECT COALESCE(SUM(r.amount), 0) FROM transactions r WHERE r.reversal_of = t.uuid)"
+
+// rollingTotals суммирует операции одного направления за скользящие сутки и 30 дней.
+func (r *WalletRepo) rollingTotals(ctx context.Context, UUID uuid.UUID, credit bool, tx pgx.Tx) (daily, monthly decimal.Decimal, err error) {
+	sql, args, err := Builder().
+		Select().
+		Column(squirrel.Expr("COALESCE(SUM("+velocityAmount+") FILTER (WHERE t.created_at > CURRENT_TIMESTAMP - make_interval(secs => ?)), 0)",
+			model.DailyLimitWindow.Seconds())).
+		Column("COALESCE(SUM(" + velocityAmount + "), 0)").
+		From("transactions t").
+		Where(squirrel.Eq{"t.wallet_uuid": UUID}).
+		Where(squirrel.Eq{"t.transaction_type": model.VelocityOperationTypes(credit)}).
+		Where(squirrel.Expr("t.created_at > CURRENT_TIMESTAMP - make_interval(secs => ?)", model.MonthlyLimitWindow.Seconds())).
+		ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for rollingTotals: %v", err)
+		return decimal.Zero, decimal.Zero, err
+	}
+
+	if err = tx.QueryRow(ctx, sql, args...).Scan(&daily, &monthly); err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Errorf("Error reading rolling totals: %v", err)
+		return decimal.Zero, decimal.Zero, err
+	}
+	return daily, monthly, nil
+}
+
+// GetWalletLimits возвращает собственные лимиты кошелька без учёта значений по умолчанию.
+func (r *WalletRepo) GetWalletLimits(ctx context.Context, UUID uuid.UUID) (model.Limits, error) {
+	return r.walletLimits(ctx, UUID, r.PgxPool)
+}
+
+// SetWalletLimits целиком заменяет собственные лимиты кошелька.
+func (r *WalletRepo) SetWalletLimits(ctx context.Context, UUID uuid.UUID, limits model.Limits) (model.Limits, error) {
+	values := append([]any{UUID}, limitValues(limits)...)
+	sql, args, err := Builder().Insert("wallet_limits").
+		Columns(append([]string{"wallet_uuid"}, limitColumns...)...).
+		Values(values...).
+		Suffix(`ON CONFLICT (wallet_uuid) DO UPDATE SET
+			max_operation = EXCLUDED.max_operation,
+			max_balance = EXCLUDED.max_balance,
+			daily_deposit = EXCLUDED.daily_deposit,
+			daily_withdrawal = EXCLUDED.daily_withdrawal,
+			monthly_deposit = EXCLUDED.monthly_deposit,
+			monthly_withdrawal = EXCLUDED.monthly_withdrawal,
+			updated_at = CURRENT_TIMESTAMP`).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for SetWalletLimits: %v", err)
+		return model.Limits{}, err
+	}
+
+	if _, err = r.PgxPool.Exec(ctx, sql, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+			return model.Limits{}, model.NewWalletNotFoundError(UUID)
+		}
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Errorf("Error saving wallet limits: %v", err)
+		return model.Limits{}, err
+	}
+	return limits, nil
+}
+
+func limitValues(limits model.Limits) []any {
+	return []any{limits.MaxOperation, limits.MaxBalance, limits.DailyDeposit, limits.DailyWithdrawal,
+		limits.MonthlyDeposit, limits.MonthlyWithdrawal}
+}
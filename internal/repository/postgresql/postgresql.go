@@ -22,12 +22,14 @@ type PgxPool interface {
 	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
 	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
 	Ping(ctx context.Context) error
+	Stat() *pgxpool.Stat
 }
 
 type Postgres struct {
 	maxPoolSize  int
 	connAttempts int
 	connTimeout  time.Duration
+	tracer       pgx.QueryTracer
 	Pool         PgxPool
 }
 
@@ -51,6 +53,13 @@ func WithConnTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithTracer подключает трассировку каждого SQL-запроса пула.
+func WithTracer(tracer pgx.QueryTracer) Option {
+	return func(p *Postgres) {
+		p.tracer = tracer
+	}
+}
+
 func NewPostgres(config Config, opts ...Option) (*Postgres, error) {
 	pg := &Postgres{
 		maxPoolSize:  90,
@@ -70,6 +79,10 @@ func NewPostgres(config Config, opts ...Option) (*Postgres, error) {
 	}
 
 	poolConfig.MaxConns = int32(pg.maxPoolSize)
+	poolConfig.ConnConfig.Tracer = pg.tracer
+	// Столбцы времени — TIMESTAMP без зоны, CURRENT_TIMESTAMP пишется в них во времени
+	// сессии, а сервис передаёт границы в UTC. Сессия в UTC делает их сравнимыми.
+	poolConfig.ConnConfig.RuntimeParams["timezone"] = "UTC"
 
 	for pg.connAttempts > 0 {
 		pg.Pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
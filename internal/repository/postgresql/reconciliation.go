@@ -0,0 +1,118 @@
+package postgresql
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+type ReconciliationRepo struct {
+	PgxPool
+	log *logrus.Logger
+}
+
+func NewReconciliationRepo(postgresql PgxPool, log *logrus.Logger) ReconciliationRepo {
+	return ReconciliationRepo{PgxPool: postgresql, log: log}
+}
+
+func (r *ReconciliationRepo) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, r.log)
+}
+
+// ReconcileWallets сверяет пачку кошельков с UUID больше after. Баланс и обе суммы читаются
+// одним запросом без блокировок, поэтому сверка видит согласованный снимок и не мешает операциям.
+func (r *ReconciliationRepo) ReconcileWallets(ctx context.Context, after uuid.UUID, limit int) ([]model.WalletReconciliation, error) {
+	credit, creditArgs, err := squirrel.Eq{"t.transaction_type": model.CreditOperationTypes}.ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build credit condition for ReconcileWallets: %v", err)
+		return nil, err
+	}
+
+	sql, args, err := Builder().Select("w.uuid", "w.balance").
+		Column(squirrel.Expr("(SELECT COALESCE(SUM(CASE WHEN "+credit+" THEN t.amount ELSE -t.amount END), 0)"+
+			" FROM transactions t WHERE t.wallet_uuid = w.uuid)", creditArgs...)).
+		Column("(SELECT COALESCE(SUM(CASE e.side WHEN 'CREDIT' THEN e.amount ELSE -e.amount END), 0)" +
+			" FROM ledger_entries e WHERE e.account = 'wallet:' || w.uuid)").
+		From("wallets w").
+		Where(squirrel.Gt{"w.uuid": after}).
+		OrderBy("w.uuid").
+		Limit(uint64(limit)).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for ReconcileWallets: %v", err)
+		return nil, err
+	}
+
+	rows, err := r.PgxPool.Query(ctx, sql, args...)
+	if err != nil {
+		r.logger(ctx).Errorf("Error executing query for ReconcileWallets: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wallets []model.WalletReconciliation
+	for rows.Next() {
+		var wallet model.WalletReconciliation
+		if err = rows.Scan(&wallet.WalletID, &wallet.Balance, &wallet.TransactionsBalance, &wallet.LedgerBalance); err != nil {
+			r.logger(ctx).Errorf("Error scanning wallet reconciliation: %v", err)
+			return nil, err
+		}
+		wallet.Drift = wallet.Balance.Sub(wallet.TransactionsBalance)
+		wallets = append(wallets, wallet)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger(ctx).Errorf("Error reading wallet reconciliation: %v", err)
+		return nil, err
+	}
+	return wallets, nil
+}
+
+func (r *ReconciliationRepo) SaveReconciliationReport(ctx context.Context, report model.ReconciliationReport) error {
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to begin transaction: %v", err)
+		return err
+	}
+	defer func() {
+		if err != nil {
+			rollback(ctx, tx, r.logger(ctx))
+		}
+	}()
+
+	sql, args, err := Builder().Insert("reconciliation_runs").
+		Columns("uuid", "started_at", "finished_at", "wallets_checked", "mismatches").
+		Values(report.RunID, report.StartedAt, report.FinishedAt, report.WalletsChecked, len(report.Mismatches)).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build insert query for reconciliation run: %v", err)
+		return err
+	}
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		r.logger(ctx).Errorf("Error saving reconciliation run %s: %v", report.RunID, err)
+		return err
+	}
+
+	if len(report.Mismatches) > 0 {
+		rows := make([][]any, 0, len(report.Mismatches))
+		for _, mismatch := range report.Mismatches {
+			rows = append(rows, []any{report.RunID, mismatch.WalletID, mismatch.Balance,
+				mismatch.TransactionsBalance, mismatch.LedgerBalance, mismatch.Drift})
+		}
+		_, err = tx.CopyFrom(ctx, pgx.Identifier{"reconciliation_findings"},
+			[]string{"run_uuid", "wallet_uuid", "balance", "transactions_balance", "ledger_balance", "drift"},
+			pgx.CopyFromRows(rows))
+		if err != nil {
+			r.logger(ctx).Errorf("Error saving reconciliation findings for run %s: %v", report.RunID, err)
+			return err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		r.logger(ctx).Errorf("Failed to commit transaction: %v", err)
+		return err
+	}
+	return nil
+}
@@ -2,114 +2,708 @@ package postgresql
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/metrics"
 	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+	numericOverflowCode     = "22003"
+)
+
+var errTransactionNotFound = errors.New("transaction not found")
+
+var transactionColumns = []string{"uuid", "wallet_uuid", "transaction_type", "amount", "currency", "balance_after", "transfer_uuid",
+	"exchange_rate", "exchange_spread", "reversal_of", "created_at"}
+
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// transactionDest возвращает приёмники для transactionColumns.
+func transactionDest(record *model.TransactionRecord) []any {
+	return []any{&record.UUID, &record.WalletID, &record.OperationType, &record.Amount,
+		&record.Currency, &record.BalanceAfter, &record.TransferID, &record.ExchangeRate, &record.Spread, &record.ReversalOf, &record.CreatedAt}
+}
+
+func scanTransactionRecord(row pgx.Row) (model.TransactionRecord, error) {
+	var record model.TransactionRecord
+	err := row.Scan(transactionDest(&record)...)
+	return record, err
+}
+
+// rollback откатывает транзакцию и тогда, когда ctx уже отменён: иначе ROLLBACK
+// не будет отправлен, и соединение вернётся в пул с незавершённой транзакцией.
+func rollback(ctx context.Context, tx pgx.Tx, log *logrus.Entry) {
+	if err := tx.Rollback(context.WithoutCancel(ctx)); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		log.Errorf("Failed to roll back transaction: %v", err)
+	}
+}
+
+func (r *WalletRepo) rollback(ctx context.Context, tx pgx.Tx) {
+	rollback(ctx, tx, r.logger(ctx))
+}
+
+// commit выделяет COMMIT в отдельный спан: на нём видно ожидание fsync и репликации.
+func (r *WalletRepo) commit(ctx context.Context, tx pgx.Tx) error {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.Commit")
+	err := tx.Commit(ctx)
+	tracing.End(span, err)
+	return err
+}
+
 type WalletRepo struct {
 	PgxPool
+	// DefaultLimits действуют по валюте кошелька, если у него нет собственного значения лимита.
+	DefaultLimits model.CurrencyLimits
+	log           *logrus.Logger
+}
+
+func NewWalletRepo(postgresql PgxPool, log *logrus.Logger) WalletRepo {
+	return WalletRepo{PgxPool: postgresql, log: log}
 }
 
-func NewWalletRepo(postgresql PgxPool) WalletRepo {
-	return WalletRepo{PgxPool: postgresql}
+func (r *WalletRepo) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, r.log)
 }
 
 func (r *WalletRepo) GetWallet(ctx context.Context, UUID uuid.UUID) (model.Wallet, error) {
-	sql, args, err := Builder().Select("uuid", "balance", "created_at").
+	sql, args, err := Builder().Select("uuid", "owner_id", "balance", "balance - held", "currency", "status", "created_at").
 		From("wallets").
 		Where(squirrel.Eq{"uuid": UUID}).ToSql()
 	if err != nil {
-		logrus.Errorf("Failed to build query for GetWallet: %v", err)
+		r.logger(ctx).Errorf("Failed to build query for GetWallet: %v", err)
 		return model.Wallet{}, err
 	}
 
 	var wallet model.Wallet
-	err = r.PgxPool.QueryRow(ctx, sql, args...).Scan(&wallet.UUID, &wallet.Balance, &wallet.CreatedAt)
+	err = r.PgxPool.QueryRow(ctx, sql, args...).Scan(&wallet.UUID, &wallet.OwnerID, &wallet.Balance, &wallet.AvailableBalance, &wallet.Currency, &wallet.Status, &wallet.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Warn("Wallet not found")
+		return model.Wallet{}, model.NewWalletNotFoundError(UUID)
+	}
 	if err != nil {
-		logrus.Errorf("Error executing query for GetWallet with UUID %s: %v", UUID, err)
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Errorf("Error executing query for GetWallet: %v", err)
 		return model.Wallet{}, err
 	}
 
 	return wallet, nil
 }
 
-func (r *WalletRepo) ProcessTransaction(ctx context.Context, wallet model.Wallet, transaction model.Transaction) (uuid.UUID, error) {
+func (r *WalletRepo) CreateWallet(ctx context.Context, wallet model.Wallet) (model.Wallet, error) {
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to begin transaction: %v", err)
+		return model.Wallet{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			r.rollback(ctx, tx)
+			r.logger(ctx).Errorf("Transaction rolled back due to error: %v", err)
+		}
+	}()
+
+	created, err := r.InsertWallet(ctx, wallet, tx)
+	if err != nil {
+		return model.Wallet{}, err
+	}
+
+	// Начальный баланс фиксируется как депозит, чтобы журнал транзакций сходился с балансом,
+	// и проходит те же лимиты, что и любое зачисление.
+	if created.Balance.IsPositive() {
+		deposit := model.Transaction{
+			WalletID:      created.UUID,
+			OperationType: model.Deposit,
+			Amount:        created.Balance,
+			Currency:      created.Currency,
+		}
+		if err = r.enforceLimits(ctx, deposit, created.Balance, tx); err != nil {
+			return model.Wallet{}, err
+		}
+
+		var record model.TransactionRecord
+		record, err = r.SaveTransaction(ctx, deposit, created.Balance, tx)
+		if err != nil {
+			r.logger(ctx).WithField(logger.FieldWalletUUID, created.UUID).Errorf("Failed to save initial deposit: %v", err)
+			return model.Wallet{}, err
+		}
+		if err = r.SavePosting(ctx, model.CashPosting(record), tx); err != nil {
+			return model.Wallet{}, err
+		}
+	}
+
+	if err = r.commit(ctx, tx); err != nil {
+		r.logger(ctx).Errorf("Failed to commit transaction: %v", err)
+		return model.Wallet{}, err
+	}
+
+	return created, nil
+}
+
+func (r *WalletRepo) InsertWallet(ctx context.Context, wallet model.Wallet, tx pgx.Tx) (model.Wallet, error) {
+	columns := []string{"owner_id", "balance", "currency"}
+	values := []interface{}{wallet.OwnerID, wallet.Balance, wallet.Currency}
+	if wallet.UUID != uuid.Nil {
+		columns = append(columns, "uuid")
+		values = append(values, wallet.UUID)
+	}
+
+	sql, args, err := Builder().Insert("wallets").
+		Columns(columns...).
+		Values(values...).
+		Suffix("RETURNING uuid, owner_id, balance, balance - held, currency, status, created_at").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build insert query for InsertWallet: %v", err)
+		return model.Wallet{}, err
+	}
+
+	var created model.Wallet
+	err = tx.QueryRow(ctx, sql, args...).Scan(&created.UUID, &created.OwnerID, &created.Balance, &created.AvailableBalance, &created.Currency, &created.Status, &created.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			r.logger(ctx).WithField(logger.FieldWalletUUID, wallet.UUID).Warn("Wallet already exists")
+			return model.Wallet{}, model.ErrWalletAlreadyExists
+		}
+		r.logger(ctx).Errorf("Error inserting wallet: %v", err)
+		return model.Wallet{}, err
+	}
+
+	return created, nil
+}
+
+func (r *WalletRepo) ProcessTransaction(ctx context.Context, transaction model.Transaction) (model.TransactionRecord, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.ProcessTransaction")
+	record, err := r.processTransaction(ctx, transaction)
+
+	var pgErr *pgconn.PgError
+	if transaction.IdempotencyKey != "" && errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		// Параллельный запрос с тем же ключом зафиксировался раньше — отдаём его результат.
+		r.logger(ctx).WithField(logger.FieldWalletUUID, transaction.WalletID).
+			Infof("Concurrent request with idempotency key %s already committed", transaction.IdempotencyKey)
+		record, err = r.replayTransaction(ctx, transaction, r.PgxPool)
+	}
+	tracing.End(span, err)
+	return record, err
+}
+
+func (r *WalletRepo) processTransaction(ctx context.Context, transaction model.Transaction) (model.TransactionRecord, error) {
+	log := r.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldWalletUUID: transaction.WalletID,
+		logger.FieldOperation:  transaction.OperationType,
+	})
+
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Failed to begin transaction: %v", err)
+		return model.TransactionRecord{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			r.rollback(ctx, tx)
+			log.Warnf("Transaction rolled back due to error: %v", err)
+		}
+	}()
+
+	if transaction.IdempotencyKey != "" {
+		var record model.TransactionRecord
+		record, err = r.replayTransaction(ctx, transaction, tx)
+		if err == nil {
+			r.rollback(ctx, tx)
+			return record, nil
+		}
+		if !errors.Is(err, errTransactionNotFound) {
+			return model.TransactionRecord{}, err
+		}
+	}
+
+	if transaction.ReversalOf != nil {
+		if err = r.lockReversible(ctx, transaction, tx); err != nil {
+			return model.TransactionRecord{}, err
+		}
+	}
+
+	balance, err := r.UpdatedWallet(ctx, transaction, tx)
+	if err != nil {
+		log.Warnf("Failed to update wallet: %v", err)
+		return model.TransactionRecord{}, err
+	}
+
+	record, err := r.SaveTransaction(ctx, transaction, balance, tx)
+	if err != nil {
+		log.Errorf("Failed to save transaction: %v", err)
+		return model.TransactionRecord{}, err
+	}
+
+	if err = r.SavePosting(ctx, model.CashPosting(record), tx); err != nil {
+		return model.TransactionRecord{}, err
+	}
+
+	if err = r.commit(ctx, tx); err != nil {
+		log.WithField(logger.FieldTransactionUUID, record.UUID).Errorf("Failed to commit transaction: %v", err)
+		return model.TransactionRecord{}, err
+	}
+
+	return record, nil
+}
+
+// replayTransaction ищет операцию, уже выполненную с тем же ключом идемпотентности.
+// Совпадающий запрос получает исходную запись, отличающийся — ошибку конфликта.
+func (r *WalletRepo) replayTransaction(ctx context.Context, transaction model.Transaction, q querier) (model.TransactionRecord, error) {
+	record, err := r.GetTransactionByIdempotencyKey(ctx, transaction.IdempotencyKey, q)
+	if err != nil {
+		return model.TransactionRecord{}, err
+	}
+
+	if !transaction.SamePayload(record) {
+		r.logger(ctx).WithFields(logrus.Fields{
+			logger.FieldWalletUUID:      transaction.WalletID,
+			logger.FieldTransactionUUID: record.UUID,
+		}).Warnf("Idempotency key %s reused with a different payload", transaction.IdempotencyKey)
+		return model.TransactionRecord{}, model.ErrIdempotencyKeyConflict
+	}
+	return record, nil
+}
+
+func (r *WalletRepo) GetTransactionByIdempotencyKey(ctx context.Context, key string, q querier) (model.TransactionRecord, error) {
+	sql, args, err := Builder().Select(transactionColumns...).
+		From("transactions").
+		Where(squirrel.Eq{"idempotency_key": key}).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for GetTransactionByIdempotencyKey: %v", err)
+		return model.TransactionRecord{}, err
+	}
+
+	record, err := scanTransactionRecord(q.QueryRow(ctx, sql, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.TransactionRecord{}, errTransactionNotFound
+	}
+	if err != nil {
+		r.logger(ctx).Errorf("Error executing query for GetTransactionByIdempotencyKey with key %s: %v", key, err)
+		return model.TransactionRecord{}, err
+	}
+
+	return record, nil
+}
+
+func (r *WalletRepo) ProcessTransfer(ctx context.Context, transfer model.Transfer) (result model.TransferResult, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.ProcessTransfer")
+	defer func() { tracing.End(span, err) }()
+
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to begin transaction: %v", err)
+		return model.TransferResult{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			r.rollback(ctx, tx)
+			r.logger(ctx).Errorf("Transaction rolled back due to error: %v", err)
+		}
+	}()
+
+	result = model.TransferResult{TransferID: uuid.New()}
+	debit, credit := transfer.Legs(result.TransferID)
+
+	debitRecord, creditRecord, err := r.moveFunds(ctx, tx, debit, credit)
+	if err != nil {
+		return model.TransferResult{}, err
+	}
+	if err = r.SavePosting(ctx, model.TransferPosting(result.TransferID, debitRecord, creditRecord), tx); err != nil {
+		return model.TransferResult{}, err
+	}
+	result.FromTransactionID = debitRecord.UUID
+	result.ToTransactionID = creditRecord.UUID
+
+	if err = r.commit(ctx, tx); err != nil {
+		r.logger(ctx).Errorf("Failed to commit transaction: %v", err)
+		return model.TransferResult{}, err
+	}
+
+	return result, nil
+}
+
+func (r *WalletRepo) ProcessExchange(ctx context.Context, order model.ExchangeOrder) (result model.ExchangeResult, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.ProcessExchange")
+	defer func() { tracing.End(span, err) }()
+
 	tx, err := r.PgxPool.Begin(ctx)
 	if err != nil {
-		logrus.Errorf("Failed to begin transaction: %v", err)
-		return uuid.Nil, err
+		r.logger(ctx).Errorf("Failed to begin transaction: %v", err)
+		return model.ExchangeResult{}, err
 	}
 
 	defer func() {
 		if err != nil {
-			tx.Rollback(ctx)
-			logrus.Errorf("Transaction rolled back due to error: %v", err)
+			r.rollback(ctx, tx)
+			r.logger(ctx).Errorf("Transaction rolled back due to error: %v", err)
 		}
 	}()
 
-	err = r.UpdatedWallet(ctx, wallet, tx)
+	debitRecord, creditRecord, err := r.moveFunds(ctx, tx, order.Debit, order.Credit)
 	if err != nil {
-		logrus.Errorf("Failed to update wallet with UUID %s: %v", wallet.UUID, err)
-		return uuid.Nil, err
+		return model.ExchangeResult{}, err
+	}
+	if err = r.SavePosting(ctx, model.ExchangePosting(order.ID, debitRecord, creditRecord), tx); err != nil {
+		return model.ExchangeResult{}, err
+	}
+
+	if err = r.commit(ctx, tx); err != nil {
+		r.logger(ctx).Errorf("Failed to commit transaction: %v", err)
+		return model.ExchangeResult{}, err
+	}
+
+	return model.ExchangeResult{
+		ExchangeID:        order.ID,
+		FromTransactionID: debitRecord.UUID,
+		ToTransactionID:   creditRecord.UUID,
+		DebitAmount:       debitRecord.Amount,
+		DebitCurrency:     debitRecord.Currency,
+		CreditAmount:      creditRecord.Amount,
+		CreditCurrency:    creditRecord.Currency,
+		Rate:              order.Rate.Rate,
+		Spread:            order.Rate.Spread,
+	}, nil
+}
+
+// moveFunds списывает debit и зачисляет credit в рамках tx, заранее заблокировав оба кошелька.
+func (r *WalletRepo) moveFunds(ctx context.Context, tx pgx.Tx, debit, credit model.Transaction) (model.TransactionRecord, model.TransactionRecord, error) {
+	if err := r.lockWallets(ctx, tx, debit.WalletID, credit.WalletID); err != nil {
+		return model.TransactionRecord{}, model.TransactionRecord{}, err
 	}
 
-	transactionUUID, err := r.SaveTransaction(ctx, transaction, tx)
+	debitBalance, err := r.UpdatedWallet(ctx, debit, tx)
 	if err != nil {
-		logrus.Errorf("Failed to save transaction for WalletID %s: %v", transaction.WalletID, err)
-		return uuid.Nil, err
+		r.logger(ctx).WithField(logger.FieldWalletUUID, debit.WalletID).Warnf("Failed to debit wallet: %v", err)
+		return model.TransactionRecord{}, model.TransactionRecord{}, err
+	}
+	creditBalance, err := r.UpdatedWallet(ctx, credit, tx)
+	if err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, credit.WalletID).Warnf("Failed to credit wallet: %v", err)
+		return model.TransactionRecord{}, model.TransactionRecord{}, err
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		logrus.Errorf("Failed to commit transaction: %v", err)
-		return uuid.Nil, err
+	debitRecord, err := r.SaveTransaction(ctx, debit, debitBalance, tx)
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to save debit leg of %s: %v", debit.TransferID, err)
+		return model.TransactionRecord{}, model.TransactionRecord{}, err
 	}
+	creditRecord, err := r.SaveTransaction(ctx, credit, creditBalance, tx)
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to save credit leg of %s: %v", credit.TransferID, err)
+		return model.TransactionRecord{}, model.TransactionRecord{}, err
+	}
+	return debitRecord, creditRecord, nil
+}
 
-	return transactionUUID, nil
+// lockWallets блокирует строки кошельков в порядке возрастания UUID, чтобы встречные
+// переводы между одной парой кошельков не приводили к взаимоблокировке.
+func (r *WalletRepo) lockWallets(ctx context.Context, tx pgx.Tx, UUIDs ...uuid.UUID) error {
+	sql, args, err := Builder().Select("uuid").
+		From("wallets").
+		Where(squirrel.Eq{"uuid": UUIDs}).
+		OrderBy("uuid").
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for lockWallets: %v", err)
+		return err
+	}
+
+	started := time.Now()
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		r.logger(ctx).Errorf("Error locking wallets %v: %v", UUIDs, err)
+		return err
+	}
+	defer rows.Close()
+	defer metrics.ObserveLockWait("lock_wallets", started)
+
+	locked := make(map[uuid.UUID]bool, len(UUIDs))
+	for rows.Next() {
+		var UUID uuid.UUID
+		if err = rows.Scan(&UUID); err != nil {
+			r.logger(ctx).Errorf("Error scanning locked wallet: %v", err)
+			return err
+		}
+		locked[UUID] = true
+	}
+	if err = rows.Err(); err != nil {
+		r.logger(ctx).Errorf("Error locking wallets %v: %v", UUIDs, err)
+		return err
+	}
+
+	for _, UUID := range UUIDs {
+		if !locked[UUID] {
+			r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Warn("Wallet not found")
+			return model.NewWalletNotFoundError(UUID)
+		}
+	}
+	return nil
 }
 
-func (r *WalletRepo) UpdatedWallet(ctx context.Context, wallet model.Wallet, tx pgx.Tx) error {
+// UpdatedWallet атомарно изменяет баланс одним условным UPDATE: строка блокируется
+// самой базой, поэтому операции над разными кошельками идут параллельно, а над
+// одним — сериализуются даже между несколькими экземплярами сервиса. Операция в
+// чужой валюте или в недопустимом статусе кошелька не находит строку так же, как
+// операция без достаточных средств; средства под активными холдами списать нельзя.
+// Лимиты проверяются уже под блокировкой строки, до фиксации транзакции.
+func (r *WalletRepo) UpdatedWallet(ctx context.Context, transaction model.Transaction, tx pgx.Tx) (balance decimal.Decimal, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.UpdatedWallet")
+	defer func() { tracing.End(span, err) }()
+
+	delta := transaction.SignedAmount()
 	sql, args, err := Builder().Update("wallets").
-		Set("balance", wallet.Balance).
-		Where(squirrel.Eq{"uuid": wallet.UUID}).
+		Set("balance", squirrel.Expr("balance + ?", delta)).
+		Where(squirrel.Eq{
+			"uuid":     transaction.WalletID,
+			"currency": transaction.CurrencyCode(),
+			"status":   model.StatusesAllowing(transaction.OperationType),
+		}).
+		Where(squirrel.Expr("balance + ? >= held", delta)).
+		Suffix("RETURNING balance").
 		ToSql()
 	if err != nil {
-		logrus.Errorf("Failed to build query for UpdatedWallet: %v", err)
+		r.logger(ctx).Errorf("Failed to build query for UpdatedWallet: %v", err)
+		return decimal.Zero, err
+	}
+
+	started := time.Now()
+	err = tx.QueryRow(ctx, sql, args...).Scan(&balance)
+	metrics.ObserveLockWait("update_balance", started)
+	if err == nil {
+		if err = r.enforceLimits(ctx, transaction, balance, tx); err != nil {
+			return decimal.Zero, err
+		}
+		return balance, nil
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == numericOverflowCode {
+		// Баланс не поместился в столбец. Прочитать его в прерванной транзакции уже нельзя,
+		// поэтому в ошибке — сумма операции.
+		err = &model.LimitExceededError{Limit: model.LimitBalanceCapacity, Threshold: model.MaxStoredBalance, Attempted: transaction.Amount}
+		r.logger(ctx).WithFields(logrus.Fields{
+			logger.FieldWalletUUID: transaction.WalletID,
+			logger.FieldOperation:  transaction.OperationType,
+		}).Warnf("Operation rejected: %v", err)
+		return decimal.Zero, err
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, transaction.WalletID).Errorf("Error executing update query: %v", err)
+		return decimal.Zero, err
+	}
+
+	return decimal.Zero, r.explainMissedUpdate(ctx, transaction.WalletID, transaction.CurrencyCode(), transaction.OperationType, tx)
+}
+
+// explainMissedUpdate выясняет, почему условный UPDATE баланса не затронул строку кошелька.
+func (r *WalletRepo) explainMissedUpdate(ctx context.Context, UUID uuid.UUID, currency string, operation model.OperationType, tx pgx.Tx) error {
+	walletCurrency, status, err := r.walletState(ctx, UUID, tx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Warn("No rows updated: wallet not found")
+		return model.NewWalletNotFoundError(UUID)
+	}
+	if err != nil {
 		return err
 	}
+	if err = status.Permit(operation); err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).
+			Warnf("No rows updated: %s is not allowed for %s wallet", operation, status)
+		return err
+	}
+	if walletCurrency != currency {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).
+			Warnf("No rows updated: wallet currency %s, operation currency %s", walletCurrency, currency)
+		return model.ErrCurrencyMismatch
+	}
+	return model.ErrInsufficientFunds
+}
+
+func (r *WalletRepo) walletState(ctx context.Context, UUID uuid.UUID, tx pgx.Tx) (string, model.WalletStatus, error) {
+	sql, args, err := Builder().Select("currency", "status").
+		From("wallets").
+		Where(squirrel.Eq{"uuid": UUID}).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for walletState: %v", err)
+		return "", "", err
+	}
+
+	var (
+		currency string
+		status   model.WalletStatus
+	)
+	err = tx.QueryRow(ctx, sql, args...).Scan(&currency, &status)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Errorf("Error reading wallet state: %v", err)
+	}
+	return currency, status, err
+}
+
+func (r *WalletRepo) SaveTransaction(ctx context.Context, transaction model.Transaction, balance decimal.Decimal, tx pgx.Tx) (model.TransactionRecord, error) {
+	var idempotencyKey *string
+	if transaction.IdempotencyKey != "" {
+		idempotencyKey = &transaction.IdempotencyKey
+	}
+	var transferID *uuid.UUID
+	if transaction.TransferID != uuid.Nil {
+		transferID = &transaction.TransferID
+	}
+	var rate, spread *decimal.Decimal
+	if transaction.Exchange != nil {
+		rate, spread = &transaction.Exchange.Rate, &transaction.Exchange.Spread
+	}
+
+	sql, args, err := Builder().Insert("transactions").
+		Columns("wallet_uuid", "transaction_type", "amount", "currency", "balance_after", "idempotency_key", "transfer_uuid",
+			"exchange_rate", "exchange_spread", "reversal_of").
+		Values(transaction.WalletID, transaction.OperationType, transaction.Amount, transaction.CurrencyCode(),
+			balance, idempotencyKey, transferID, rate, spread, transaction.ReversalOf).
+		Suffix("RETURNING " + strings.Join(transactionColumns, ", ")).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build insert query for SaveTransaction: %v", err)
+		return model.TransactionRecord{}, err
+	}
+
+	record, err := scanTransactionRecord(tx.QueryRow(ctx, sql, args...))
+	if err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, transaction.WalletID).Errorf("Error saving transaction: %v", err)
+		return model.TransactionRecord{}, err
+	}
+
+	return record, nil
+}
 
-	res, err := tx.Exec(ctx, sql, args...)
+// SavePosting дописывает проводки операции в главную книгу. Записи книги не изменяются
+// и не удаляются; баланс проводки дополнительно проверяется триггером при COMMIT.
+func (r *WalletRepo) SavePosting(ctx context.Context, posting model.Posting, tx pgx.Tx) error {
+	if !posting.Balanced() {
+		r.logger(ctx).Errorf("Refusing to save unbalanced posting %s", posting.ID)
+		return model.ErrUnbalancedPosting
+	}
+
+	sql, args, err := postingInsert(posting).ToSql()
 	if err != nil {
-		logrus.Errorf("Error executing update query for wallet with UUID %s: %v", wallet.UUID, err)
+		r.logger(ctx).Errorf("Failed to build insert query for SavePosting: %v", err)
 		return err
 	}
 
-	rowsAffected := res.RowsAffected()
-	if rowsAffected == 0 {
-		logrus.Errorf("No rows updated for wallet with UUID %s", wallet.UUID)
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		r.logger(ctx).Errorf("Error saving ledger posting %s: %v", posting.ID, err)
+		return err
 	}
 	return nil
 }
 
-func (r *WalletRepo) SaveTransaction(ctx context.Context, transaction model.Transaction, tx pgx.Tx) (uuid.UUID, error) {
-	sql, args, err := Builder().Insert("transactions").
-		Columns("wallet_uuid", "transaction_type", "amount").
-		Values(transaction.WalletID, transaction.OperationType, transaction.Amount).
-		Suffix("RETURNING uuid").ToSql()
+func postingInsert(posting model.Posting) squirrel.InsertBuilder {
+	query := Builder().Insert("ledger_entries").
+		Columns("posting_uuid", "transaction_uuid", "account", "side", "amount", "currency")
+	for _, entry := range posting.Entries {
+		query = query.Values(posting.ID, entry.TransactionID, entry.Account, entry.Side, entry.Amount, entry.Currency)
+	}
+	return query
+}
+
+// GetBalanceCheck читает баланс кошелька и сумму его проводок одним запросом, то есть из одного снимка данных.
+func (r *WalletRepo) GetBalanceCheck(ctx context.Context, UUID uuid.UUID) (model.BalanceCheck, error) {
+	sql, args, err := Builder().Select("w.uuid", "w.balance",
+		"COALESCE(SUM(CASE e.side WHEN 'CREDIT' THEN e.amount ELSE -e.amount END), 0)").
+		From("wallets w").
+		LeftJoin("ledger_entries e ON e.account = ?", model.WalletAccount(UUID)).
+		Where(squirrel.Eq{"w.uuid": UUID}).
+		GroupBy("w.uuid", "w.balance").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for GetBalanceCheck: %v", err)
+		return model.BalanceCheck{}, err
+	}
+
+	var check model.BalanceCheck
+	err = r.PgxPool.QueryRow(ctx, sql, args...).Scan(&check.WalletID, &check.Balance, &check.LedgerBalance)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.BalanceCheck{}, model.NewWalletNotFoundError(UUID)
+	}
+	if err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Errorf("Error executing query for GetBalanceCheck: %v", err)
+		return model.BalanceCheck{}, err
+	}
+	return check, nil
+}
+
+func (r *WalletRepo) GetTransactions(ctx context.Context, filter model.TransactionFilter) ([]model.TransactionRecord, error) {
+	query := Builder().Select(transactionColumns...).
+		From("transactions").
+		Where(squirrel.Eq{"wallet_uuid": filter.WalletID})
+
+	if filter.OperationType != "" {
+		query = query.Where(squirrel.Eq{"transaction_type": filter.OperationType})
+	}
+	if filter.MinAmount != nil {
+		query = query.Where(squirrel.GtOrEq{"amount": *filter.MinAmount})
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where(squirrel.LtOrEq{"amount": *filter.MaxAmount})
+	}
+	if filter.From != nil {
+		query = query.Where(squirrel.GtOrEq{"created_at": *filter.From})
+	}
+	if filter.To != nil {
+		query = query.Where(squirrel.Lt{"created_at": *filter.To})
+	}
+
+	direction := "DESC"
+	if filter.Order == model.SortAsc {
+		direction = "ASC"
+	}
+	if filter.Cursor != nil {
+		comparison := "<"
+		if filter.Order == model.SortAsc {
+			comparison = ">"
+		}
+		query = query.Where(squirrel.Expr("(created_at, uuid) "+comparison+" (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.UUID))
+	}
+
+	sql, args, err := query.
+		OrderBy("created_at "+direction, "uuid "+direction).
+		Limit(uint64(filter.Limit)).ToSql()
 	if err != nil {
-		logrus.Errorf("Failed to build insert query for SaveTransaction: %v", err)
-		return uuid.Nil, err
+		r.logger(ctx).Errorf("Failed to build query for GetTransactions: %v", err)
+		return nil, err
 	}
 
-	var transactionUUID uuid.UUID
-	err = tx.QueryRow(ctx, sql, args...).Scan(&transactionUUID)
+	rows, err := r.PgxPool.Query(ctx, sql, args...)
 	if err != nil {
-		logrus.Errorf("Error saving transaction for wallet %s: %v", transaction.WalletID, err)
-		return uuid.Nil, err
+		r.logger(ctx).WithField(logger.FieldWalletUUID, filter.WalletID).Errorf("Error executing query for GetTransactions: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := make([]model.TransactionRecord, 0, filter.Limit)
+	for rows.Next() {
+		record, err := scanTransactionRecord(rows)
+		if err != nil {
+			r.logger(ctx).WithField(logger.FieldWalletUUID, filter.WalletID).Errorf("Error scanning transaction: %v", err)
+			return nil, err
+		}
+		transactions = append(transactions, record)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, filter.WalletID).Errorf("Error iterating transactions: %v", err)
+		return nil, err
 	}
 
-	return transactionUUID, nil
+	return transactions, nil
 }
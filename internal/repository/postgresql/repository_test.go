@@ -0,0 +1,465 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/repository/postgresql/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// liveContext совпадает с контекстом, который ещё не отменён.
+type liveContext struct{}
+
+func (liveContext) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	return ok && ctx.Err() == nil
+}
+
+func (liveContext) String() string { return "is a live context" }
+
+func TestWalletRepo_ProcessTransaction_CanceledContextRollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	transaction := model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Withdraw,
+		Amount:        decimal.NewFromInt32(10),
+	}
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, string, ...any) *mock.MockRow {
+			cancel()
+			return mockRow
+		})
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(...any) error {
+		return ctx.Err()
+	})
+	mockTx.EXPECT().Rollback(liveContext{}).Return(nil)
+	mockTx.EXPECT().Commit(gomock.Any()).Times(0)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	_, err := repo.ProcessTransaction(ctx, transaction)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWalletRepo_ProcessTransaction_BeginCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(nil, ctx.Err())
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	_, err := repo.ProcessTransaction(ctx, model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(10),
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWalletRepo_ProcessTransaction_SpanTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow).Times(3)
+	// Баланс после UPDATE, лимиты кошелька и строка сохранённой транзакции.
+	mockRow.EXPECT().Scan(gomock.Any()).Return(nil).Times(3)
+	mockTx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(pgconn.CommandTag{}, nil)
+	mockTx.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "handler")
+	_, err := repo.ProcessTransaction(ctx, model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(10),
+	})
+	parent.End()
+	assert.NoError(t, err)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	process := spans["WalletRepo.ProcessTransaction"]
+	assert.NotNil(t, process)
+	assert.Equal(t, spans["handler"].SpanContext().SpanID(), process.Parent().SpanID())
+	for _, name := range []string{"WalletRepo.UpdatedWallet", "WalletRepo.Commit"} {
+		assert.Contains(t, spans, name)
+		assert.Equal(t, process.SpanContext().SpanID(), spans[name].Parent().SpanID(), name)
+	}
+}
+
+func TestWalletRepo_SavePosting_WritesBalancedEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTx := mock.NewMockTx(ctrl)
+	record := model.TransactionRecord{
+		UUID:          uuid.New(),
+		WalletID:      uuid.New(),
+		OperationType: model.Withdraw,
+		Amount:        decimal.NewFromInt32(10),
+		Currency:      "USD",
+	}
+
+	mockTx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+			assert.Contains(t, sql, "INSERT INTO ledger_entries")
+			assert.Equal(t, []any{
+				record.UUID, &record.UUID, model.WalletAccount(record.WalletID), model.Debit, record.Amount, "USD",
+				record.UUID, (*uuid.UUID)(nil), model.CashAccount, model.Credit, record.Amount, "USD",
+			}, args)
+			return pgconn.CommandTag{}, nil
+		})
+
+	repo := NewWalletRepo(nil, logger.Discard())
+
+	assert.NoError(t, repo.SavePosting(context.Background(), model.CashPosting(record), mockTx))
+}
+
+func TestWalletRepo_SavePosting_RejectsUnbalanced(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTx := mock.NewMockTx(ctrl)
+	mockTx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	posting := model.Posting{ID: uuid.New(), Entries: []model.LedgerEntry{
+		{Account: model.CashAccount, Side: model.Debit, Amount: decimal.NewFromInt32(10)},
+		{Account: model.WalletAccount(uuid.New()), Side: model.Credit, Amount: decimal.NewFromInt32(9)},
+	}}
+
+	repo := NewWalletRepo(nil, logger.Discard())
+
+	assert.ErrorIs(t, repo.SavePosting(context.Background(), posting, mockTx), model.ErrUnbalancedPosting)
+}
+
+func TestWalletRepo_UpdatedWallet_CurrencyMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTx := mock.NewMockTx(ctrl)
+	updateRow := mock.NewMockRow(ctrl)
+	currencyRow := mock.NewMockRow(ctrl)
+
+	gomock.InOrder(
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(updateRow),
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(currencyRow),
+	)
+	updateRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+	currencyRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "USD"
+		*dest[1].(*model.WalletStatus) = model.WalletActive
+		return nil
+	})
+
+	repo := NewWalletRepo(nil, logger.Discard())
+
+	_, err := repo.UpdatedWallet(context.Background(), model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(10),
+		Currency:      "RUB",
+	}, mockTx)
+
+	assert.ErrorIs(t, err, model.ErrCurrencyMismatch)
+}
+
+func TestWalletRepo_UpdatedWallet_FrozenWalletRejectsWithdrawal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTx := mock.NewMockTx(ctrl)
+	updateRow := mock.NewMockRow(ctrl)
+	stateRow := mock.NewMockRow(ctrl)
+
+	gomock.InOrder(
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, sql string, args ...any) pgx.Row {
+				assert.Contains(t, sql, "status IN")
+				assert.Contains(t, args, model.WalletActive)
+				assert.NotContains(t, args, model.WalletFrozen)
+				return updateRow
+			}),
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(stateRow),
+	)
+	updateRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+	stateRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*string) = "RUB"
+		*dest[1].(*model.WalletStatus) = model.WalletFrozen
+		return nil
+	})
+
+	repo := NewWalletRepo(nil, logger.Discard())
+
+	_, err := repo.UpdatedWallet(context.Background(), model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Withdraw,
+		Amount:        decimal.NewFromInt32(10),
+	}, mockTx)
+
+	assert.ErrorIs(t, err, model.ErrWalletFrozen)
+}
+
+func TestWalletRepo_SetWalletStatus_CloseRequiresEmptyWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow)
+	mockRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*model.WalletStatus) = model.WalletFrozen
+		*dest[1].(*decimal.Decimal) = decimal.NewFromInt32(5)
+		*dest[2].(*decimal.Decimal) = decimal.Zero
+		return nil
+	})
+	mockTx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	_, err := repo.SetWalletStatus(context.Background(), model.WalletStatusChange{
+		WalletID: uuid.New(),
+		Status:   model.WalletClosed,
+		Reason:   "customer request",
+		Actor:    "compliance@example.com",
+	})
+
+	assert.ErrorIs(t, err, model.ErrWalletNotEmpty)
+}
+
+func TestWalletRepo_UpdatedWallet_DailyWithdrawalLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTx := mock.NewMockTx(ctrl)
+	updateRow := mock.NewMockRow(ctrl)
+	limitsRow := mock.NewMockRow(ctrl)
+	totalsRow := mock.NewMockRow(ctrl)
+
+	gomock.InOrder(
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(updateRow),
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(limitsRow),
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, sql string, args ...any) pgx.Row {
+				// Сумма выводов считается без отмен и за вычетом уже отменённых частей.
+				assert.Contains(t, sql, "r.reversal_of = t.uuid")
+				assert.Contains(t, args, model.Withdraw)
+				assert.NotContains(t, args, model.ReversalIn)
+				assert.NotContains(t, args, model.Deposit)
+				return totalsRow
+			}),
+	)
+	updateRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*decimal.Decimal) = decimal.NewFromInt32(500)
+		return nil
+	})
+	// У кошелька нет собственных лимитов — действуют значения по умолчанию.
+	limitsRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgx.ErrNoRows)
+	totalsRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*decimal.Decimal) = decimal.NewFromInt32(95)
+		*dest[1].(*decimal.Decimal) = decimal.NewFromInt32(95)
+		return nil
+	})
+
+	dailyLimit := decimal.NewFromInt32(100)
+	repo := NewWalletRepo(nil, logger.Discard())
+	repo.DefaultLimits = model.CurrencyLimits{"RUB": {DailyWithdrawal: &dailyLimit}}
+
+	_, err := repo.UpdatedWallet(context.Background(), model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Withdraw,
+		Amount:        decimal.NewFromInt32(10),
+	}, mockTx)
+
+	var limitErr *model.LimitExceededError
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, model.LimitDailyWithdrawal, limitErr.Limit)
+	assert.True(t, limitErr.Attempted.Equal(decimal.NewFromInt32(105)))
+	assert.ErrorIs(t, err, model.ErrLimitExceeded)
+}
+
+func TestWalletRepo_UpdatedWallet_ReversalAtDailyLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTx := mock.NewMockTx(ctrl)
+	updateRow := mock.NewMockRow(ctrl)
+	limitsRow := mock.NewMockRow(ctrl)
+
+	// Суммы за окна для отмены не читаются: возврат списанного проходит, даже когда он
+	// больше суточного лимита пополнений.
+	gomock.InOrder(
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(updateRow),
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(limitsRow),
+	)
+	updateRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*decimal.Decimal) = decimal.NewFromInt32(500)
+		return nil
+	})
+	limitsRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(dest ...any) error {
+			dailyDeposit := decimal.NewFromInt32(100)
+			*dest[2].(**decimal.Decimal) = &dailyDeposit
+			return nil
+		})
+
+	original := uuid.New()
+	repo := NewWalletRepo(nil, logger.Discard())
+
+	balance, err := repo.UpdatedWallet(context.Background(), model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.ReversalIn,
+		Amount:        decimal.NewFromInt32(300),
+		ReversalOf:    &original,
+	}, mockTx)
+
+	assert.NoError(t, err)
+	assert.True(t, balance.Equal(decimal.NewFromInt32(500)))
+}
+
+func TestWalletRepo_UpdatedWallet_BalanceOverflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTx := mock.NewMockTx(ctrl)
+	updateRow := mock.NewMockRow(ctrl)
+
+	mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(updateRow)
+	updateRow.EXPECT().Scan(gomock.Any()).Return(&pgconn.PgError{Code: numericOverflowCode, Message: "numeric field overflow"})
+
+	repo := NewWalletRepo(nil, logger.Discard())
+
+	_, err := repo.UpdatedWallet(context.Background(), model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(10),
+	}, mockTx)
+
+	var limitErr *model.LimitExceededError
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, model.LimitBalanceCapacity, limitErr.Limit)
+	assert.ErrorIs(t, err, model.ErrLimitExceeded)
+}
+
+func TestWalletRepo_CreateWallet_OpeningBalanceAboveLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	insertRow := mock.NewMockRow(ctrl)
+	limitsRow := mock.NewMockRow(ctrl)
+	walletUUID := uuid.New()
+
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	gomock.InOrder(
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(insertRow),
+		mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(limitsRow),
+	)
+	insertRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*uuid.UUID) = walletUUID
+		*dest[2].(*decimal.Decimal) = decimal.NewFromInt32(500)
+		*dest[4].(*string) = "RUB"
+		return nil
+	})
+	limitsRow.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(pgx.ErrNoRows)
+	// Депозит не записывается, транзакция откатывается вместе с кошельком.
+	mockTx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockTx.EXPECT().Commit(gomock.Any()).Times(0)
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	maxBalance := decimal.NewFromInt32(100)
+	repo := NewWalletRepo(mockPool, logger.Discard())
+	repo.DefaultLimits = model.CurrencyLimits{"RUB": {MaxBalance: &maxBalance}}
+
+	_, err := repo.CreateWallet(context.Background(), model.Wallet{
+		UUID:     walletUUID,
+		Balance:  decimal.NewFromInt32(500),
+		Currency: "RUB",
+	})
+
+	var limitErr *model.LimitExceededError
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, model.LimitMaxBalance, limitErr.Limit)
+	assert.ErrorIs(t, err, model.ErrLimitExceeded)
+}
+
+func TestWalletRepo_ProcessTransaction_ReversalExceedsRemaining(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+
+	original := uuid.New()
+	mockPool.EXPECT().Begin(gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, sql string, args ...any) pgx.Row {
+			assert.Contains(t, sql, "FOR UPDATE OF t")
+			return mockRow
+		})
+	mockRow.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		*dest[0].(*decimal.Decimal) = decimal.NewFromInt32(100)
+		*dest[1].(*decimal.Decimal) = decimal.NewFromInt32(70)
+		return nil
+	})
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	_, err := repo.ProcessTransaction(context.Background(), model.Transaction{
+		WalletID:      uuid.New(),
+		OperationType: model.ReversalIn,
+		Amount:        decimal.NewFromInt32(40),
+		ReversalOf:    &original,
+	})
+
+	assert.ErrorIs(t, err, model.ErrReversalExceedsAmount)
+}
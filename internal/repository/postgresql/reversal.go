@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+func (r *WalletRepo) GetTransaction(ctx context.Context, UUID uuid.UUID) (model.TransactionRecord, error) {
+	sql, args, err := Builder().Select(transactionColumns...).
+		From("transactions").
+		Where(squirrel.Eq{"uuid": UUID}).ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for GetTransaction: %v", err)
+		return model.TransactionRecord{}, err
+	}
+
+	record, err := scanTransactionRecord(r.PgxPool.QueryRow(ctx, sql, args...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.TransactionRecord{}, model.ErrTransactionNotFound
+	}
+	if err != nil {
+		r.logger(ctx).Errorf("Error executing query for GetTransaction with transaction %s: %v", UUID, err)
+		return model.TransactionRecord{}, err
+	}
+	return record, nil
+}
+
+// lockReversible блокирует исходную операцию до строки кошелька, чтобы параллельные отмены
+// одной операции сериализовались, и проверяет, что отмена укладывается в неотменённый остаток.
+func (r *WalletRepo) lockReversible(ctx context.Context, reversal model.Transaction, tx pgx.Tx) error {
+	sql, args, err := Builder().Select("t.amount").
+		Column("(SELECT COALESCE(SUM(r.amount), 0) FROM transactions r WHERE r.reversal_of = t.uuid)").
+		From("transactions t").
+		Where(squirrel.Eq{"t.uuid": *reversal.ReversalOf}).
+		Suffix("FOR UPDATE OF t").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for lockReversible: %v", err)
+		return err
+	}
+
+	var amount, reversed decimal.Decimal
+	err = tx.QueryRow(ctx, sql, args...).Scan(&amount, &reversed)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrTransactionNotFound
+	}
+	if err != nil {
+		r.logger(ctx).Errorf("Error locking transaction %s: %v", *reversal.ReversalOf, err)
+		return err
+	}
+
+	remaining := amount.Sub(reversed)
+	log := r.logger(ctx).WithFields(logrus.Fields{
+		"original_transaction_uuid": *reversal.ReversalOf,
+		"remaining":                 remaining,
+	})
+	if !remaining.IsPositive() {
+		log.Warn("Transaction is already fully reversed")
+		return model.ErrAlreadyReversed
+	}
+	if reversal.Amount.GreaterThan(remaining) {
+		log.Warnf("Reversal of %s exceeds the remaining amount", reversal.Amount)
+		return model.ErrReversalExceedsAmount
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// StreamStatement читает итоги выписки и её операции в одной транзакции REPEATABLE READ,
+// то есть из одного снимка: операции, проведённые во время выгрузки, не попадут ни в итоги,
+// ни в перечень. Остатки выводятся из текущего баланса кошелька вычитанием операций,
+// проведённых после конца периода. Операции передаются в line по одной, не собираясь в памяти.
+func (r *WalletRepo) StreamStatement(ctx context.Context, period model.StatementPeriod,
+	begin func(model.Statement) error, line func(model.TransactionRecord) error) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletRepo.StreamStatement")
+	defer func() { tracing.End(span, err) }()
+	log := r.logger(ctx).WithField(logger.FieldWalletUUID, period.WalletID)
+
+	tx, err := r.PgxPool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		log.Errorf("Failed to begin transaction: %v", err)
+		return err
+	}
+	// Транзакция только читает, поэтому завершается откатом и при успехе.
+	defer r.rollback(ctx, tx)
+
+	statement, err := r.statementTotals(ctx, period, tx)
+	if err != nil {
+		return err
+	}
+	if err = begin(statement); err != nil {
+		return err
+	}
+
+	sql, args, err := Builder().Select(transactionColumns...).
+		From("transactions").
+		Where(squirrel.Eq{"wallet_uuid": period.WalletID}).
+		Where(squirrel.GtOrEq{"created_at": period.From}).
+		Where(squirrel.Lt{"created_at": period.To}).
+		OrderBy("created_at", "uuid").ToSql()
+	if err != nil {
+		log.Errorf("Failed to build query for StreamStatement: %v", err)
+		return err
+	}
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		log.Errorf("Error executing query for StreamStatement: %v", err)
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record model.TransactionRecord
+		if record, err = scanTransactionRecord(rows); err != nil {
+			log.Errorf("Error scanning statement transaction: %v", err)
+			return err
+		}
+		if err = line(record); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		log.Errorf("Error reading statement transactions: %v", err)
+		return err
+	}
+	return nil
+}
+
+// statementTotals одним запросом считает остатки на границах периода, обороты и число операций.
+func (r *WalletRepo) statementTotals(ctx context.Context, period model.StatementPeriod, tx pgx.Tx) (model.Statement, error) {
+	credit, creditArgs, err := squirrel.Eq{"t.transaction_type": model.CreditOperationTypes}.ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build credit condition for statementTotals: %v", err)
+		return model.Statement{}, err
+	}
+	withCredit := func(args ...any) []any {
+		return append(append([]any{}, creditArgs...), args...)
+	}
+
+	sql, args, err := Builder().Select("w.owner_id", "w.currency", "w.balance").
+		Column(squirrel.Expr("COALESCE(SUM(CASE WHEN "+credit+" THEN t.amount ELSE -t.amount END)"+
+			" FILTER (WHERE t.created_at >= ?), 0)", withCredit(period.To)...)).
+		Column(squirrel.Expr("COALESCE(SUM(t.amount) FILTER (WHERE "+credit+" AND t.created_at < ?), 0)", withCredit(period.To)...)).
+		Column(squirrel.Expr("COALESCE(SUM(t.amount) FILTER (WHERE NOT "+credit+" AND t.created_at < ?), 0)", withCredit(period.To)...)).
+		Column(squirrel.Expr("COUNT(t.uuid) FILTER (WHERE t.created_at < ?)", period.To)).
+		From("wallets w").
+		LeftJoin("transactions t ON t.wallet_uuid = w.uuid AND t.created_at >= ?", period.From).
+		Where(squirrel.Eq{"w.uuid": period.WalletID}).
+		GroupBy("w.uuid").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for statementTotals: %v", err)
+		return model.Statement{}, err
+	}
+
+	statement := model.Statement{WalletID: period.WalletID, From: period.From, To: period.To, GeneratedAt: time.Now().UTC()}
+	var balance, afterPeriod decimal.Decimal
+	err = tx.QueryRow(ctx, sql, args...).Scan(&statement.OwnerID, &statement.Currency, &balance,
+		&afterPeriod, &statement.TotalCredits, &statement.TotalDebits, &statement.TransactionCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Statement{}, model.NewWalletNotFoundError(period.WalletID)
+	}
+	if err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, period.WalletID).Errorf("Error calculating statement totals: %v", err)
+		return model.Statement{}, err
+	}
+
+	statement.ClosingBalance = balance.Sub(afterPeriod)
+	statement.OpeningBalance = statement.ClosingBalance.Sub(statement.TotalCredits).Add(statement.TotalDebits)
+	return statement, nil
+}
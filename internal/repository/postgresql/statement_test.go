@@ -0,0 +1,83 @@
+package postgresql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/repository/postgresql/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalletRepo_StreamStatement_Totals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+	period := model.StatementPeriod{WalletID: uuid.New(), From: time.Now().Add(-time.Hour), To: time.Now(), Format: model.StatementJSON}
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}).Return(mockTx, nil)
+	mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow)
+	mockRow.EXPECT().Scan(gomock.Any()).DoAndReturn(func(dest ...any) error {
+		// Текущий баланс 200, после периода зачислено 30; за период +80 и -40.
+		*dest[1].(*string) = "RUB"
+		*dest[2].(*decimal.Decimal) = decimal.NewFromInt32(200)
+		*dest[3].(*decimal.Decimal) = decimal.NewFromInt32(30)
+		*dest[4].(*decimal.Decimal) = decimal.NewFromInt32(80)
+		*dest[5].(*decimal.Decimal) = decimal.NewFromInt32(40)
+		*dest[6].(*int64) = 2
+		return nil
+	})
+	mockTx.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRows(ctrl), nil)
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+
+	var statement model.Statement
+	err := repo.StreamStatement(context.Background(), period,
+		func(header model.Statement) error {
+			statement = header
+			return nil
+		},
+		func(model.TransactionRecord) error { return nil })
+
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt32(170).Equal(statement.ClosingBalance))
+	assert.True(t, decimal.NewFromInt32(130).Equal(statement.OpeningBalance))
+	assert.Equal(t, int64(2), statement.TransactionCount)
+}
+
+func TestWalletRepo_StreamStatement_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := mock.NewMockPgxPool(ctrl)
+	mockTx := mock.NewMockTx(ctrl)
+	mockRow := mock.NewMockRow(ctrl)
+	period := model.StatementPeriod{WalletID: uuid.New(), From: time.Now().Add(-time.Hour), To: time.Now(), Format: model.StatementJSON}
+
+	mockPool.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
+	mockTx.EXPECT().QueryRow(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockRow)
+	mockRow.EXPECT().Scan(gomock.Any()).Return(pgx.ErrNoRows)
+	mockTx.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	repo := NewWalletRepo(mockPool, logger.Discard())
+	err := repo.StreamStatement(context.Background(), period,
+		func(model.Statement) error {
+			t.Fatal("begin must not be called for a missing wallet")
+			return nil
+		},
+		func(model.TransactionRecord) error { return nil })
+
+	var notFound *model.WalletNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, period.WalletID, notFound.UUID)
+}
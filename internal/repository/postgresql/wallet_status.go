@@ -0,0 +1,138 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// SetWalletStatus меняет статус под блокировкой строки кошелька и записывает смену в аудит
+// в той же транзакции, поэтому операции, начатые после фиксации, уже видят новый статус.
+func (r *WalletRepo) SetWalletStatus(ctx context.Context, change model.WalletStatusChange) (wallet model.Wallet, err error) {
+	log := r.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldWalletUUID: change.WalletID,
+		"status":               change.Status,
+	})
+
+	tx, err := r.PgxPool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Failed to begin transaction: %v", err)
+		return model.Wallet{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			r.rollback(ctx, tx)
+			log.Warnf("Transaction rolled back due to error: %v", err)
+		}
+	}()
+
+	sql, args, err := Builder().Select("status", "balance", "held").
+		From("wallets").
+		Where(squirrel.Eq{"uuid": change.WalletID}).
+		Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		log.Errorf("Failed to build query for SetWalletStatus: %v", err)
+		return model.Wallet{}, err
+	}
+
+	var (
+		current       model.WalletStatus
+		balance, held decimal.Decimal
+	)
+	err = tx.QueryRow(ctx, sql, args...).Scan(&current, &balance, &held)
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = model.NewWalletNotFoundError(change.WalletID)
+		return model.Wallet{}, err
+	}
+	if err != nil {
+		log.Errorf("Error locking wallet: %v", err)
+		return model.Wallet{}, err
+	}
+
+	if !current.CanTransitionTo(change.Status) {
+		log.Warnf("Wallet status transition from %s is not allowed", current)
+		err = model.ErrInvalidStatusChange
+		return model.Wallet{}, err
+	}
+	if change.Status == model.WalletClosed && (!balance.IsZero() || !held.IsZero()) {
+		log.Warnf("Wallet with balance %s and held %s cannot be closed", balance, held)
+		err = model.ErrWalletNotEmpty
+		return model.Wallet{}, err
+	}
+
+	sql, args, err = Builder().Update("wallets").
+		Set("status", change.Status).
+		Where(squirrel.Eq{"uuid": change.WalletID}).
+		Suffix("RETURNING uuid, owner_id, balance, balance - held, currency, status, created_at").ToSql()
+	if err != nil {
+		log.Errorf("Failed to build update query for SetWalletStatus: %v", err)
+		return model.Wallet{}, err
+	}
+	err = tx.QueryRow(ctx, sql, args...).Scan(&wallet.UUID, &wallet.OwnerID, &wallet.Balance, &wallet.AvailableBalance,
+		&wallet.Currency, &wallet.Status, &wallet.CreatedAt)
+	if err != nil {
+		log.Errorf("Error updating wallet status: %v", err)
+		return model.Wallet{}, err
+	}
+
+	sql, args, err = Builder().Insert("wallet_status_audit").
+		Columns("wallet_uuid", "from_status", "to_status", "reason", "actor").
+		Values(change.WalletID, current, change.Status, change.Reason, change.Actor).ToSql()
+	if err != nil {
+		log.Errorf("Failed to build insert query for wallet_status_audit: %v", err)
+		return model.Wallet{}, err
+	}
+	if _, err = tx.Exec(ctx, sql, args...); err != nil {
+		log.Errorf("Error saving wallet status audit: %v", err)
+		return model.Wallet{}, err
+	}
+
+	if err = r.commit(ctx, tx); err != nil {
+		log.Errorf("Failed to commit transaction: %v", err)
+		return model.Wallet{}, err
+	}
+	return wallet, nil
+}
+
+// GetWalletStatusHistory возвращает смены статуса кошелька от новых к старым.
+func (r *WalletRepo) GetWalletStatusHistory(ctx context.Context, UUID uuid.UUID) ([]model.WalletStatusAudit, error) {
+	sql, args, err := Builder().Select("id", "wallet_uuid", "from_status", "to_status", "reason", "actor", "created_at").
+		From("wallet_status_audit").
+		Where(squirrel.Eq{"wallet_uuid": UUID}).
+		OrderBy("created_at DESC", "id DESC").ToSql()
+	if err != nil {
+		r.logger(ctx).Errorf("Failed to build query for GetWalletStatusHistory: %v", err)
+		return nil, err
+	}
+
+	rows, err := r.PgxPool.Query(ctx, sql, args...)
+	if err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Errorf("Error executing query for GetWalletStatusHistory: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]model.WalletStatusAudit, 0)
+	for rows.Next() {
+		var audit model.WalletStatusAudit
+		if err = rows.Scan(&audit.ID, &audit.WalletID, &audit.FromStatus, &audit.ToStatus, &audit.Reason,
+			&audit.Actor, &audit.CreatedAt); err != nil {
+			r.logger(ctx).Errorf("Error scanning wallet status audit: %v", err)
+			return nil, err
+		}
+		history = append(history, audit)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Errorf("Error reading wallet status history: %v", err)
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/dannamer/JavaCode-test/internal/bulk"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=bulk.go -destination=mock/bulk_mock.go -package=mock
+type RepoBulk interface {
+	ImportWallets(ctx context.Context, source model.WalletImportSource) (int64, error)
+	ExportWallets(ctx context.Context, format model.BulkFormat, w io.Writer) (int64, error)
+}
+
+type BulkService struct {
+	RepoBulk
+	// defaults — лимиты по умолчанию, которым должен соответствовать начальный баланс кошелька.
+	defaults model.CurrencyLimits
+	log      *logrus.Logger
+}
+
+func NewBulkService(repo RepoBulk, defaults model.CurrencyLimits, log *logrus.Logger) BulkService {
+	return BulkService{RepoBulk: repo, defaults: defaults, log: log}
+}
+
+func (s *BulkService) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, s.log)
+}
+
+// Import разбирает поток во время COPY, не читая его в память целиком. Импорт либо
+// проходит полностью, либо отклоняется с перечнем строк в *model.WalletImportError.
+func (s *BulkService) Import(ctx context.Context, format model.BulkFormat, body io.Reader) (model.WalletImportResult, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "BulkService.Import")
+	imported, err := s.ImportWallets(ctx, bulk.NewWalletReader(format, body, s.defaults))
+	tracing.End(span, err)
+
+	log := s.logger(ctx).WithField("format", format)
+	var invalid *model.WalletImportError
+	if errors.As(err, &invalid) {
+		log.WithField("invalid_lines", len(invalid.Errors)).Warn("Wallet import rejected")
+		return model.WalletImportResult{}, err
+	}
+	if err != nil {
+		log.Errorf("Wallet import failed: %v", err)
+		return model.WalletImportResult{}, err
+	}
+
+	log.WithField("imported", imported).Info("Wallets imported")
+	return model.WalletImportResult{Format: format, Imported: imported}, nil
+}
+
+func (s *BulkService) Export(ctx context.Context, format model.BulkFormat, w io.Writer) (int64, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "BulkService.Export")
+	exported, err := s.ExportWallets(ctx, format, w)
+	tracing.End(span, err)
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger(ctx).WithFields(logrus.Fields{"format": format, "exported": exported}).Info("Wallets exported")
+	return exported, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkService_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoBulk(ctrl)
+	mockRepo.EXPECT().ImportWallets(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, source model.WalletImportSource) (int64, error) {
+			var copied int64
+			for source.Next() {
+				copied++
+			}
+			return copied, source.Err()
+		})
+
+	bulkService := NewBulkService(mockRepo, model.CurrencyLimits{}, logger.Discard())
+
+	result, err := bulkService.Import(context.Background(), model.BulkCSV, strings.NewReader("balance,currency\n10,RUB\n20,USD\n"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.WalletImportResult{Format: model.BulkCSV, Imported: 2}, result)
+}
+
+func TestBulkService_Import_InvalidLines(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoBulk(ctrl)
+	mockRepo.EXPECT().ImportWallets(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, source model.WalletImportSource) (int64, error) {
+			for source.Next() {
+			}
+			return 0, source.Err()
+		})
+
+	bulkService := NewBulkService(mockRepo, model.CurrencyLimits{}, logger.Discard())
+
+	_, err := bulkService.Import(context.Background(), model.BulkNDJSON, strings.NewReader(`{"balance":"abc"}`))
+
+	assert.ErrorIs(t, err, model.ErrInvalidImport)
+}
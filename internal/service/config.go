@@ -0,0 +1,147 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/shopspring/decimal"
+)
+
+type ReconciliationConfig struct {
+	// Interval — период фоновой сверки; ноль отключает её.
+	Interval  time.Duration
+	BatchSize int
+}
+
+type SnapshotConfig struct {
+	// Interval — как часто проверять, снят ли снимок балансов на последнюю полночь;
+	// ноль отключает снимки, и исторический баланс считается от текущего.
+	Interval  time.Duration
+	BatchSize int
+}
+
+type HoldConfig struct {
+	// ExpiryInterval — как часто воркер освобождает истёкшие холды.
+	ExpiryInterval time.Duration
+}
+
+func NewHoldConfig() (*HoldConfig, error) {
+	config := &HoldConfig{ExpiryInterval: 30 * time.Second}
+
+	if raw := os.Getenv("HOLD_EXPIRY_INTERVAL"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid duration in HOLD_EXPIRY_INTERVAL: %q", raw)
+		}
+		config.ExpiryInterval = interval
+	}
+
+	return config, nil
+}
+
+func NewReconciliationConfig() (*ReconciliationConfig, error) {
+	config := &ReconciliationConfig{BatchSize: model.DefaultReconciliationBatchSize}
+
+	if raw := os.Getenv("RECONCILIATION_INTERVAL"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval < 0 {
+			return nil, fmt.Errorf("invalid duration in RECONCILIATION_INTERVAL: %q", raw)
+		}
+		config.Interval = interval
+	}
+
+	if raw := os.Getenv("RECONCILIATION_BATCH_SIZE"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid batch size in RECONCILIATION_BATCH_SIZE: %q", raw)
+		}
+		config.BatchSize = size
+	}
+
+	return config, nil
+}
+
+func NewSnapshotConfig() (*SnapshotConfig, error) {
+	config := &SnapshotConfig{BatchSize: model.DefaultSnapshotBatchSize}
+
+	if raw := os.Getenv("BALANCE_SNAPSHOT_INTERVAL"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval < 0 {
+			return nil, fmt.Errorf("invalid duration in BALANCE_SNAPSHOT_INTERVAL: %q", raw)
+		}
+		config.Interval = interval
+	}
+
+	if raw := os.Getenv("BALANCE_SNAPSHOT_BATCH_SIZE"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid batch size in BALANCE_SNAPSHOT_BATCH_SIZE: %q", raw)
+		}
+		config.BatchSize = size
+	}
+
+	return config, nil
+}
+
+type LimitsConfig struct {
+	// Defaults действуют для кошельков без собственных лимитов и задаются по валютам
+	// переменными LIMIT_<KIND>_<CURRENCY>; пустая переменная — без лимита.
+	Defaults model.CurrencyLimits
+}
+
+var limitFields = map[string]func(*model.Limits) **decimal.Decimal{
+	"MAX_OPERATION":      func(l *model.Limits) **decimal.Decimal { return &l.MaxOperation },
+	"MAX_BALANCE":        func(l *model.Limits) **decimal.Decimal { return &l.MaxBalance },
+	"DAILY_DEPOSIT":      func(l *model.Limits) **decimal.Decimal { return &l.DailyDeposit },
+	"DAILY_WITHDRAWAL":   func(l *model.Limits) **decimal.Decimal { return &l.DailyWithdrawal },
+	"MONTHLY_DEPOSIT":    func(l *model.Limits) **decimal.Decimal { return &l.MonthlyDeposit },
+	"MONTHLY_WITHDRAWAL": func(l *model.Limits) **decimal.Decimal { return &l.MonthlyWithdrawal },
+}
+
+// NewLimitsConfig читает лимиты по умолчанию после регистрации пользовательских валют:
+// значения сверяются с точностью своей валюты.
+func NewLimitsConfig() (*LimitsConfig, error) {
+	config := &LimitsConfig{Defaults: model.CurrencyLimits{}}
+
+	for _, entry := range os.Environ() {
+		env, raw, _ := strings.Cut(entry, "=")
+		name, ok := strings.CutPrefix(env, "LIMIT_")
+		if !ok || raw == "" {
+			continue
+		}
+
+		var field func(*model.Limits) **decimal.Decimal
+		var currency string
+		for kind, f := range limitFields {
+			if code, found := strings.CutPrefix(name, kind+"_"); found {
+				field, currency = f, code
+				break
+			}
+		}
+		if field == nil {
+			return nil, fmt.Errorf("unknown limit variable %s, expected LIMIT_<KIND>_<CURRENCY>", env)
+		}
+		if _, ok := model.LookupCurrency(currency); !ok {
+			return nil, fmt.Errorf("unknown currency in %s: %q", env, currency)
+		}
+		value, err := decimal.NewFromString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount in %s: %q", env, raw)
+		}
+
+		limits := config.Defaults[currency]
+		*field(&limits) = &value
+		config.Defaults[currency] = limits
+	}
+
+	for currency, limits := range config.Defaults {
+		if !limits.Validate() || !limits.ValidIn(currency) {
+			return nil, fmt.Errorf("default limits for %s must be non-negative, below %s and fit the currency precision", currency, model.MaxAmount)
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/metrics"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=exchange.go -destination=mock/exchange_mock.go -package=mock
+type RepoExchange interface {
+	GetWallet(ctx context.Context, UUID uuid.UUID) (model.Wallet, error)
+	ProcessExchange(ctx context.Context, order model.ExchangeOrder) (model.ExchangeResult, error)
+}
+
+type ExchangeRateProvider interface {
+	Rate(ctx context.Context, base, quote string) (model.ExchangeRate, error)
+}
+
+type ExchangeService struct {
+	RepoExchange
+	ExchangeRateProvider
+	log *logrus.Logger
+}
+
+func NewExchangeService(repo RepoExchange, rates ExchangeRateProvider, log *logrus.Logger) ExchangeService {
+	return ExchangeService{RepoExchange: repo, ExchangeRateProvider: rates, log: log}
+}
+
+func (s *ExchangeService) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, s.log)
+}
+
+// ExchangeFunds фиксирует курс до начала транзакции БД, чтобы обращение к провайдеру
+// не удерживало блокировки кошельков. Валюта кошелька не меняется, поэтому прочитанные
+// здесь валюты остаются верными; репозиторий всё равно перепроверяет их при списании.
+func (s *ExchangeService) ExchangeFunds(ctx context.Context, exchange model.Exchange) (model.ExchangeResult, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "ExchangeService.ExchangeFunds")
+	result, err := s.exchangeFunds(ctx, exchange)
+	tracing.End(span, err)
+	metrics.ObserveOperation(metrics.OperationExchange, err)
+
+	log := s.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldOperation: metrics.OperationExchange,
+		"from_wallet_uuid":    exchange.FromWalletID,
+		"to_wallet_uuid":      exchange.ToWalletID,
+	})
+	if err != nil {
+		log.WithField(logger.FieldOutcome, metrics.Outcome(err)).Info("Exchange rejected")
+		return model.ExchangeResult{}, err
+	}
+	log.WithFields(logrus.Fields{
+		logger.FieldTransferUUID: result.ExchangeID,
+		logger.FieldAmount:       exchange.Amount,
+		"rate":                   result.Rate,
+	}).Info("Exchange completed")
+	return result, nil
+}
+
+func (s *ExchangeService) exchangeFunds(ctx context.Context, exchange model.Exchange) (model.ExchangeResult, error) {
+	from, err := s.GetWallet(ctx, exchange.FromWalletID)
+	if err != nil {
+		return model.ExchangeResult{}, err
+	}
+	to, err := s.GetWallet(ctx, exchange.ToWalletID)
+	if err != nil {
+		return model.ExchangeResult{}, err
+	}
+	if from.Currency == to.Currency {
+		return model.ExchangeResult{}, model.ErrInvalidExchange
+	}
+
+	if !model.ValidAmountIn(from.Currency, exchange.Amount) {
+		return model.ExchangeResult{}, model.ErrInvalidExchange
+	}
+	toCurrency, ok := model.LookupCurrency(to.Currency)
+	if !ok {
+		return model.ExchangeResult{}, model.ErrInvalidExchange
+	}
+
+	rate, err := s.Rate(ctx, from.Currency, to.Currency)
+	if err != nil {
+		return model.ExchangeResult{}, err
+	}
+	credit := rate.Convert(exchange.Amount, toCurrency.Precision)
+	// Слишком малая сумма после округления обнуляется, а слишком большая после умножения
+	// на курс может выйти за MaxAmount и переполнить баланс получателя.
+	if !credit.IsPositive() || !model.ValidAmountIn(to.Currency, credit) {
+		return model.ExchangeResult{}, model.ErrInvalidExchange
+	}
+
+	return s.ProcessExchange(ctx, model.NewExchangeOrder(exchange, rate, credit))
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/exchange"
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRates(t *testing.T) *exchange.StaticRates {
+	rates, err := exchange.NewStaticRates([]model.ExchangeRate{{
+		Base:   "USD",
+		Quote:  "RUB",
+		Rate:   decimal.RequireFromString("80"),
+		Spread: decimal.RequireFromString("0.01"),
+	}})
+	assert.NoError(t, err)
+	return rates
+}
+
+func TestExchangeService_ExchangeFunds_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoExchange(ctrl)
+	from := model.Wallet{UUID: uuid.New(), Currency: "RUB"}
+	to := model.Wallet{UUID: uuid.New(), Currency: "USD"}
+	request := model.Exchange{FromWalletID: from.UUID, ToWalletID: to.UUID, Amount: decimal.RequireFromString("1000")}
+
+	mockRepo.EXPECT().GetWallet(gomock.Any(), from.UUID).Return(from, nil)
+	mockRepo.EXPECT().GetWallet(gomock.Any(), to.UUID).Return(to, nil)
+	mockRepo.EXPECT().ProcessExchange(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, order model.ExchangeOrder) (model.ExchangeResult, error) {
+			// 1000 RUB * 0.0125 * 0.99 = 12.375 USD, округлено вниз до центов.
+			assert.True(t, order.Credit.Amount.Equal(decimal.RequireFromString("12.37")), order.Credit.Amount.String())
+			assert.Equal(t, model.ExchangeOut, order.Debit.OperationType)
+			assert.Equal(t, "RUB", order.Debit.Currency)
+			assert.Equal(t, "USD", order.Credit.Currency)
+			assert.Equal(t, order.ID, order.Credit.TransferID)
+
+			posting := model.ExchangePosting(order.ID,
+				model.TransactionRecord{WalletID: from.UUID, OperationType: order.Debit.OperationType, Amount: order.Debit.Amount, Currency: order.Debit.Currency},
+				model.TransactionRecord{WalletID: to.UUID, OperationType: order.Credit.OperationType, Amount: order.Credit.Amount, Currency: order.Credit.Currency})
+			assert.True(t, posting.Balanced())
+
+			return model.ExchangeResult{ExchangeID: order.ID, CreditAmount: order.Credit.Amount}, nil
+		})
+
+	exchangeService := NewExchangeService(mockRepo, newTestRates(t), logger.Discard())
+
+	result, err := exchangeService.ExchangeFunds(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, result.ExchangeID)
+}
+
+func TestExchangeService_ExchangeFunds_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		amount   string
+		err      error
+	}{
+		{"same currency", "USD", "USD", "10", model.ErrInvalidExchange},
+		{"amount below source precision", "USD", "RUB", "10.001", model.ErrInvalidExchange},
+		{"credit rounds to zero", "RUB", "USD", "0.01", model.ErrInvalidExchange},
+		{"amount above max amount", "USD", "RUB", "1000000000000000000", model.ErrInvalidExchange},
+		{"credit above max amount", "USD", "RUB", "100000000000000000", model.ErrInvalidExchange},
+		{"no rate", "USD", "EUR", "10", model.ErrExchangeRateNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepoExchange(ctrl)
+			from := model.Wallet{UUID: uuid.New(), Currency: tt.from}
+			to := model.Wallet{UUID: uuid.New(), Currency: tt.to}
+
+			mockRepo.EXPECT().GetWallet(gomock.Any(), from.UUID).Return(from, nil)
+			mockRepo.EXPECT().GetWallet(gomock.Any(), to.UUID).Return(to, nil)
+			mockRepo.EXPECT().ProcessExchange(gomock.Any(), gomock.Any()).Times(0)
+
+			exchangeService := NewExchangeService(mockRepo, newTestRates(t), logger.Discard())
+
+			_, err := exchangeService.ExchangeFunds(context.Background(), model.Exchange{
+				FromWalletID: from.UUID,
+				ToWalletID:   to.UUID,
+				Amount:       decimal.RequireFromString(tt.amount),
+			})
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+)
+
+//go:generate mockgen -source=health.go -destination=mock/health_mock.go -package=mock
+type RepoHealth interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (uint, bool, error)
+	PoolStats() model.PoolStats
+}
+
+type HealthService struct {
+	RepoHealth
+	expectedVersion uint
+}
+
+// NewHealthService принимает версию схемы, до которой мигрировал этот экземпляр при старте.
+func NewHealthService(repoHealth RepoHealth, expectedVersion uint) HealthService {
+	return HealthService{RepoHealth: repoHealth, expectedVersion: expectedVersion}
+}
+
+func (s *HealthService) Readiness(ctx context.Context) model.Readiness {
+	readiness := model.Readiness{
+		Ready:  true,
+		Checks: map[string]string{},
+		Pool:   s.PoolStats(),
+	}
+
+	if err := s.Ping(ctx); err != nil {
+		readiness.Ready = false
+		readiness.Checks["database"] = err.Error()
+	} else {
+		readiness.Checks["database"] = model.CheckOK
+	}
+
+	version, dirty, err := s.MigrationVersion(ctx)
+	readiness.MigrationVersion = version
+	switch {
+	case err != nil:
+		readiness.Ready = false
+		readiness.Checks["migrations"] = err.Error()
+	case dirty:
+		readiness.Ready = false
+		readiness.Checks["migrations"] = fmt.Sprintf("version %d is dirty", version)
+	case version < s.expectedVersion:
+		readiness.Ready = false
+		readiness.Checks["migrations"] = fmt.Sprintf("version %d is behind expected %d", version, s.expectedVersion)
+	default:
+		readiness.Checks["migrations"] = model.CheckOK
+	}
+
+	// Насыщение пула только отражается в ответе: запросы встанут в очередь, а не упадут.
+	if readiness.Pool.MaxConns > 0 && readiness.Pool.AcquiredConns >= readiness.Pool.MaxConns {
+		readiness.Checks["pool"] = model.CheckSaturated
+	} else {
+		readiness.Checks["pool"] = model.CheckOK
+	}
+
+	return readiness
+}
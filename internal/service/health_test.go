@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthService_Readiness_Ready(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoHealth(ctrl)
+	stats := model.PoolStats{AcquiredConns: 3, IdleConns: 2, TotalConns: 5, MaxConns: 10, Saturation: 0.3}
+
+	mockRepo.EXPECT().PoolStats().Return(stats)
+	mockRepo.EXPECT().Ping(context.Background()).Return(nil)
+	mockRepo.EXPECT().MigrationVersion(context.Background()).Return(uint(6), false, nil)
+
+	healthService := NewHealthService(mockRepo, 6)
+
+	readiness := healthService.Readiness(context.Background())
+
+	assert.True(t, readiness.Ready)
+	assert.Equal(t, uint(6), readiness.MigrationVersion)
+	assert.Equal(t, stats, readiness.Pool)
+	assert.Equal(t, map[string]string{"database": model.CheckOK, "migrations": model.CheckOK, "pool": model.CheckOK}, readiness.Checks)
+}
+
+func TestHealthService_Readiness_DatabaseDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoHealth(ctrl)
+
+	mockRepo.EXPECT().PoolStats().Return(model.PoolStats{MaxConns: 10})
+	mockRepo.EXPECT().Ping(context.Background()).Return(errors.New("connection refused"))
+	mockRepo.EXPECT().MigrationVersion(context.Background()).Return(uint(0), false, errors.New("connection refused"))
+
+	healthService := NewHealthService(mockRepo, 6)
+
+	readiness := healthService.Readiness(context.Background())
+
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, "connection refused", readiness.Checks["database"])
+}
+
+func TestHealthService_Readiness_MigrationBehind(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoHealth(ctrl)
+
+	mockRepo.EXPECT().PoolStats().Return(model.PoolStats{MaxConns: 10})
+	mockRepo.EXPECT().Ping(context.Background()).Return(nil)
+	mockRepo.EXPECT().MigrationVersion(context.Background()).Return(uint(5), false, nil)
+
+	healthService := NewHealthService(mockRepo, 6)
+
+	readiness := healthService.Readiness(context.Background())
+
+	assert.False(t, readiness.Ready)
+	assert.Equal(t, "version 5 is behind expected 6", readiness.Checks["migrations"])
+}
+
+func TestHealthService_Readiness_PoolSaturatedStillReady(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoHealth(ctrl)
+
+	mockRepo.EXPECT().PoolStats().Return(model.PoolStats{AcquiredConns: 10, TotalConns: 10, MaxConns: 10, Saturation: 1})
+	mockRepo.EXPECT().Ping(context.Background()).Return(nil)
+	mockRepo.EXPECT().MigrationVersion(context.Background()).Return(uint(6), false, nil)
+
+	healthService := NewHealthService(mockRepo, 6)
+
+	readiness := healthService.Readiness(context.Background())
+
+	assert.True(t, readiness.Ready)
+	assert.Equal(t, model.CheckSaturated, readiness.Checks["pool"])
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// holdExpiryBatchSize ограничивает число холдов, закрываемых одним запросом воркера.
+const holdExpiryBatchSize = 500
+
+//go:generate mockgen -source=hold.go -destination=mock/hold_mock.go -package=mock
+type RepoHold interface {
+	CreateHold(ctx context.Context, request model.HoldRequest) (model.Hold, error)
+	GetHold(ctx context.Context, UUID uuid.UUID) (model.Hold, error)
+	CaptureHold(ctx context.Context, UUID uuid.UUID, amount decimal.Decimal) (model.HoldCaptureResult, error)
+	ReleaseHold(ctx context.Context, UUID uuid.UUID) (model.Hold, error)
+	ExpireHolds(ctx context.Context, limit int) (int64, error)
+}
+
+type HoldService struct {
+	RepoHold
+	log *logrus.Logger
+}
+
+func NewHoldService(repo RepoHold, log *logrus.Logger) HoldService {
+	return HoldService{RepoHold: repo, log: log}
+}
+
+func (s *HoldService) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, s.log)
+}
+
+func (s *HoldService) PlaceHold(ctx context.Context, request model.HoldRequest) (model.Hold, error) {
+	hold, err := s.CreateHold(ctx, request)
+	if err != nil {
+		return model.Hold{}, err
+	}
+	s.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldWalletUUID: hold.WalletID,
+		logger.FieldAmount:     hold.Amount,
+		"hold_uuid":            hold.UUID,
+	}).Info("Hold created")
+	return hold, nil
+}
+
+// Capture проверяет сумму по уже сохранённому холду: сумма и валюта холда не меняются,
+// а его статус репозиторий перепроверяет под блокировкой.
+func (s *HoldService) Capture(ctx context.Context, UUID uuid.UUID, capture model.HoldCapture) (model.HoldCaptureResult, error) {
+	hold, err := s.GetHold(ctx, UUID)
+	if err != nil {
+		return model.HoldCaptureResult{}, err
+	}
+
+	amount := hold.Amount
+	if capture.Amount != nil {
+		amount = *capture.Amount
+	}
+	if amount.GreaterThan(hold.Amount) {
+		return model.HoldCaptureResult{}, model.ErrCaptureExceedsHold
+	}
+	if currency, ok := model.LookupCurrency(hold.Currency); !ok || !currency.ValidAmount(amount) {
+		return model.HoldCaptureResult{}, model.ErrInvalidAmount
+	}
+
+	result, err := s.CaptureHold(ctx, UUID, amount)
+	if err != nil {
+		return model.HoldCaptureResult{}, err
+	}
+	s.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldWalletUUID:      hold.WalletID,
+		logger.FieldTransactionUUID: result.Transaction.UUID,
+		logger.FieldAmount:          amount,
+		"hold_uuid":                 UUID,
+	}).Info("Hold captured")
+	return result, nil
+}
+
+func (s *HoldService) Release(ctx context.Context, UUID uuid.UUID) (model.Hold, error) {
+	hold, err := s.ReleaseHold(ctx, UUID)
+	if err != nil {
+		return model.Hold{}, err
+	}
+	s.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldWalletUUID: hold.WalletID,
+		"hold_uuid":            UUID,
+	}).Info("Hold released")
+	return hold, nil
+}
+
+// ExpireDue закрывает все истёкшие холды пачками.
+func (s *HoldService) ExpireDue(ctx context.Context) (int64, error) {
+	var total int64
+	for {
+		expired, err := s.ExpireHolds(ctx, holdExpiryBatchSize)
+		total += expired
+		if err != nil || expired < holdExpiryBatchSize {
+			return total, err
+		}
+	}
+}
+
+// RunExpiry запускает ExpireDue с заданным интервалом до отмены ctx.
+func (s *HoldService) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := s.ExpireDue(ctx)
+			if err != nil && ctx.Err() == nil {
+				s.logger(ctx).Errorf("Hold expiry failed: %v", err)
+			}
+			if expired > 0 {
+				s.logger(ctx).WithField("expired", expired).Info("Expired holds released")
+			}
+		}
+	}
+}
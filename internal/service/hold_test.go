@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoldService_Capture_Partial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoHold(ctrl)
+	hold := model.Hold{UUID: uuid.New(), WalletID: uuid.New(), Amount: decimal.NewFromInt32(100), Currency: "RUB", Status: model.HoldActive}
+	amount := decimal.RequireFromString("40.5")
+
+	mockRepo.EXPECT().GetHold(gomock.Any(), hold.UUID).Return(hold, nil)
+	mockRepo.EXPECT().CaptureHold(gomock.Any(), hold.UUID, amount).Return(model.HoldCaptureResult{Hold: hold}, nil)
+
+	holdService := NewHoldService(mockRepo, logger.Discard())
+
+	_, err := holdService.Capture(context.Background(), hold.UUID, model.HoldCapture{Amount: &amount})
+
+	assert.NoError(t, err)
+}
+
+func TestHoldService_Capture_WholeHoldByDefault(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoHold(ctrl)
+	hold := model.Hold{UUID: uuid.New(), WalletID: uuid.New(), Amount: decimal.NewFromInt32(100), Currency: "RUB", Status: model.HoldActive}
+
+	mockRepo.EXPECT().GetHold(gomock.Any(), hold.UUID).Return(hold, nil)
+	mockRepo.EXPECT().CaptureHold(gomock.Any(), hold.UUID, hold.Amount).Return(model.HoldCaptureResult{Hold: hold}, nil)
+
+	holdService := NewHoldService(mockRepo, logger.Discard())
+
+	_, err := holdService.Capture(context.Background(), hold.UUID, model.HoldCapture{})
+
+	assert.NoError(t, err)
+}
+
+func TestHoldService_Capture_ExceedsHold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoHold(ctrl)
+	hold := model.Hold{UUID: uuid.New(), Amount: decimal.NewFromInt32(100), Currency: "RUB", Status: model.HoldActive}
+	amount := decimal.NewFromInt32(101)
+
+	mockRepo.EXPECT().GetHold(gomock.Any(), hold.UUID).Return(hold, nil)
+	mockRepo.EXPECT().CaptureHold(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	holdService := NewHoldService(mockRepo, logger.Discard())
+
+	_, err := holdService.Capture(context.Background(), hold.UUID, model.HoldCapture{Amount: &amount})
+
+	assert.ErrorIs(t, err, model.ErrCaptureExceedsHold)
+}
+
+func TestHoldService_ExpireDue_DrainsInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoHold(ctrl)
+	gomock.InOrder(
+		mockRepo.EXPECT().ExpireHolds(gomock.Any(), holdExpiryBatchSize).Return(int64(holdExpiryBatchSize), nil),
+		mockRepo.EXPECT().ExpireHolds(gomock.Any(), holdExpiryBatchSize).Return(int64(3), nil),
+	)
+
+	holdService := NewHoldService(mockRepo, logger.Discard())
+
+	expired, err := holdService.ExpireDue(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(holdExpiryBatchSize+3), expired)
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=limits.go -destination=mock/limits_mock.go -package=mock
+type RepoLimits interface {
+	GetWallet(ctx context.Context, UUID uuid.UUID) (model.Wallet, error)
+	GetWalletLimits(ctx context.Context, UUID uuid.UUID) (model.Limits, error)
+	SetWalletLimits(ctx context.Context, UUID uuid.UUID, limits model.Limits) (model.Limits, error)
+}
+
+type LimitsService struct {
+	RepoLimits
+	defaults model.CurrencyLimits
+	log      *logrus.Logger
+}
+
+func NewLimitsService(repo RepoLimits, defaults model.CurrencyLimits, log *logrus.Logger) LimitsService {
+	return LimitsService{RepoLimits: repo, defaults: defaults, log: log}
+}
+
+func (s *LimitsService) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, s.log)
+}
+
+func (s *LimitsService) WalletLimits(ctx context.Context, UUID uuid.UUID) (model.WalletLimits, error) {
+	wallet, err := s.GetWallet(ctx, UUID)
+	if err != nil {
+		return model.WalletLimits{}, err
+	}
+	own, err := s.GetWalletLimits(ctx, UUID)
+	if err != nil {
+		return model.WalletLimits{}, err
+	}
+	return model.WalletLimits{WalletID: UUID, Own: own, Effective: s.defaults.For(wallet.Currency).Override(own)}, nil
+}
+
+// ChangeWalletLimits сверяет точность лимитов с валютой кошелька: лимит в долях цента
+// не сравним ни с одной допустимой суммой операции.
+func (s *LimitsService) ChangeWalletLimits(ctx context.Context, UUID uuid.UUID, limits model.Limits) (model.WalletLimits, error) {
+	wallet, err := s.GetWallet(ctx, UUID)
+	if err != nil {
+		return model.WalletLimits{}, err
+	}
+	if !limits.ValidIn(wallet.Currency) {
+		return model.WalletLimits{}, model.ErrInvalidLimits
+	}
+
+	own, err := s.SetWalletLimits(ctx, UUID, limits)
+	if err != nil {
+		return model.WalletLimits{}, err
+	}
+	s.logger(ctx).WithField(logger.FieldWalletUUID, UUID).Info("Wallet limits changed")
+	return model.WalletLimits{WalletID: UUID, Own: own, Effective: s.defaults.For(wallet.Currency).Override(own)}, nil
+}
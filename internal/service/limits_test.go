@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitsService_WalletLimits_DefaultsOverridden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoLimits(ctrl)
+	walletUUID := uuid.New()
+	defaultBalance, defaultOperation := decimal.NewFromInt32(1000), decimal.NewFromInt32(100)
+	ownOperation := decimal.NewFromInt32(50)
+
+	mockRepo.EXPECT().GetWallet(gomock.Any(), walletUUID).Return(model.Wallet{UUID: walletUUID, Currency: "RUB"}, nil)
+	mockRepo.EXPECT().GetWalletLimits(gomock.Any(), walletUUID).Return(model.Limits{MaxOperation: &ownOperation}, nil)
+
+	limitsService := NewLimitsService(mockRepo, model.CurrencyLimits{"RUB": {MaxBalance: &defaultBalance, MaxOperation: &defaultOperation}}, logger.Discard())
+
+	limits, err := limitsService.WalletLimits(context.Background(), walletUUID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &ownOperation, limits.Effective.MaxOperation)
+	assert.Equal(t, &defaultBalance, limits.Effective.MaxBalance)
+	assert.Nil(t, limits.Own.MaxBalance)
+}
+
+func TestLimitsService_WalletLimits_NoDefaultsForCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoLimits(ctrl)
+	walletUUID := uuid.New()
+	defaultBalance := decimal.NewFromInt32(1000)
+
+	mockRepo.EXPECT().GetWallet(gomock.Any(), walletUUID).Return(model.Wallet{UUID: walletUUID, Currency: "USD"}, nil)
+	mockRepo.EXPECT().GetWalletLimits(gomock.Any(), walletUUID).Return(model.Limits{}, nil)
+
+	limitsService := NewLimitsService(mockRepo, model.CurrencyLimits{"RUB": {MaxBalance: &defaultBalance}}, logger.Discard())
+
+	limits, err := limitsService.WalletLimits(context.Background(), walletUUID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.Limits{}, limits.Effective)
+}
+
+func TestLimitsService_WalletLimits_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoLimits(ctrl)
+	walletUUID := uuid.New()
+
+	mockRepo.EXPECT().GetWallet(gomock.Any(), walletUUID).Return(model.Wallet{}, model.NewWalletNotFoundError(walletUUID))
+	mockRepo.EXPECT().GetWalletLimits(gomock.Any(), gomock.Any()).Times(0)
+
+	limitsService := NewLimitsService(mockRepo, model.CurrencyLimits{}, logger.Discard())
+
+	_, err := limitsService.WalletLimits(context.Background(), walletUUID)
+
+	assert.ErrorIs(t, err, model.ErrWalletNotFound)
+}
+
+func TestLimitsService_ChangeWalletLimits_ResetToDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoLimits(ctrl)
+	walletUUID := uuid.New()
+	defaultBalance := decimal.NewFromInt32(1000)
+
+	mockRepo.EXPECT().GetWallet(gomock.Any(), walletUUID).Return(model.Wallet{UUID: walletUUID, Currency: "RUB"}, nil)
+	mockRepo.EXPECT().SetWalletLimits(gomock.Any(), walletUUID, model.Limits{}).Return(model.Limits{}, nil)
+
+	limitsService := NewLimitsService(mockRepo, model.CurrencyLimits{"RUB": {MaxBalance: &defaultBalance}}, logger.Discard())
+
+	limits, err := limitsService.ChangeWalletLimits(context.Background(), walletUUID, model.Limits{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.Limits{}, limits.Own)
+	assert.Equal(t, model.Limits{MaxBalance: &defaultBalance}, limits.Effective)
+}
+
+func TestLimitsService_ChangeWalletLimits_WrongPrecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoLimits(ctrl)
+	walletUUID := uuid.New()
+	limit := decimal.RequireFromString("10.001")
+
+	mockRepo.EXPECT().GetWallet(gomock.Any(), walletUUID).Return(model.Wallet{UUID: walletUUID, Currency: "RUB"}, nil)
+	mockRepo.EXPECT().SetWalletLimits(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	limitsService := NewLimitsService(mockRepo, model.CurrencyLimits{}, logger.Discard())
+
+	_, err := limitsService.ChangeWalletLimits(context.Background(), walletUUID, model.Limits{DailyDeposit: &limit})
+
+	assert.ErrorIs(t, err, model.ErrInvalidLimits)
+}
+
+func TestLimitsService_ChangeWalletLimits_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoLimits(ctrl)
+	walletUUID := uuid.New()
+
+	mockRepo.EXPECT().GetWallet(gomock.Any(), walletUUID).Return(model.Wallet{}, model.NewWalletNotFoundError(walletUUID))
+	mockRepo.EXPECT().SetWalletLimits(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	limitsService := NewLimitsService(mockRepo, model.CurrencyLimits{}, logger.Discard())
+
+	_, err := limitsService.ChangeWalletLimits(context.Background(), walletUUID, model.Limits{})
+
+	assert.ErrorIs(t, err, model.ErrWalletNotFound)
+}
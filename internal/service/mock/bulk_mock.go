@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bulk.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoBulk is a mock of RepoBulk interface.
+type MockRepoBulk struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoBulkMockRecorder
+}
+
+// MockRepoBulkMockRecorder is the mock recorder for MockRepoBulk.
+type MockRepoBulkMockRecorder struct {
+	mock *MockRepoBulk
+}
+
+// NewMockRepoBulk creates a new mock instance.
+func NewMockRepoBulk(ctrl *gomock.Controller) *MockRepoBulk {
+	mock := &MockRepoBulk{ctrl: ctrl}
+	mock.recorder = &MockRepoBulkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoBulk) EXPECT() *MockRepoBulkMockRecorder {
+	return m.recorder
+}
+
+// ExportWallets mocks base method.
+func (m *MockRepoBulk) ExportWallets(ctx context.Context, format model.BulkFormat, w io.Writer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportWallets", ctx, format, w)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportWallets indicates an expected call of ExportWallets.
+func (mr *MockRepoBulkMockRecorder) ExportWallets(ctx, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportWallets", reflect.TypeOf((*MockRepoBulk)(nil).ExportWallets), ctx, format, w)
+}
+
+// ImportWallets mocks base method.
+func (m *MockRepoBulk) ImportWallets(ctx context.Context, source model.WalletImportSource) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportWallets", ctx, source)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportWallets indicates an expected call of ImportWallets.
+func (mr *MockRepoBulkMockRecorder) ImportWallets(ctx, source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportWallets", reflect.TypeOf((*MockRepoBulk)(nil).ImportWallets), ctx, source)
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: exchange.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepoExchange is a mock of RepoExchange interface.
+type MockRepoExchange struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoExchangeMockRecorder
+}
+
+// MockRepoExchangeMockRecorder is the mock recorder for MockRepoExchange.
+type MockRepoExchangeMockRecorder struct {
+	mock *MockRepoExchange
+}
+
+// NewMockRepoExchange creates a new mock instance.
+func NewMockRepoExchange(ctrl *gomock.Controller) *MockRepoExchange {
+	mock := &MockRepoExchange{ctrl: ctrl}
+	mock.recorder = &MockRepoExchangeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoExchange) EXPECT() *MockRepoExchangeMockRecorder {
+	return m.recorder
+}
+
+// GetWallet mocks base method.
+func (m *MockRepoExchange) GetWallet(ctx context.Context, UUID uuid.UUID) (model.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", ctx, UUID)
+	ret0, _ := ret[0].(model.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockRepoExchangeMockRecorder) GetWallet(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockRepoExchange)(nil).GetWallet), ctx, UUID)
+}
+
+// ProcessExchange mocks base method.
+func (m *MockRepoExchange) ProcessExchange(ctx context.Context, order model.ExchangeOrder) (model.ExchangeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessExchange", ctx, order)
+	ret0, _ := ret[0].(model.ExchangeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessExchange indicates an expected call of ProcessExchange.
+func (mr *MockRepoExchangeMockRecorder) ProcessExchange(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessExchange", reflect.TypeOf((*MockRepoExchange)(nil).ProcessExchange), ctx, order)
+}
+
+// MockExchangeRateProvider is a mock of ExchangeRateProvider interface.
+type MockExchangeRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRateProviderMockRecorder
+}
+
+// MockExchangeRateProviderMockRecorder is the mock recorder for MockExchangeRateProvider.
+type MockExchangeRateProviderMockRecorder struct {
+	mock *MockExchangeRateProvider
+}
+
+// NewMockExchangeRateProvider creates a new mock instance.
+func NewMockExchangeRateProvider(ctrl *gomock.Controller) *MockExchangeRateProvider {
+	mock := &MockExchangeRateProvider{ctrl: ctrl}
+	mock.recorder = &MockExchangeRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRateProvider) EXPECT() *MockExchangeRateProviderMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockExchangeRateProvider) Rate(ctx context.Context, base, quote string) (model.ExchangeRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, base, quote)
+	ret0, _ := ret[0].(model.ExchangeRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockExchangeRateProviderMockRecorder) Rate(ctx, base, quote interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockExchangeRateProvider)(nil).Rate), ctx, base, quote)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoHealth is a mock of RepoHealth interface.
+type MockRepoHealth struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoHealthMockRecorder
+}
+
+// MockRepoHealthMockRecorder is the mock recorder for MockRepoHealth.
+type MockRepoHealthMockRecorder struct {
+	mock *MockRepoHealth
+}
+
+// NewMockRepoHealth creates a new mock instance.
+func NewMockRepoHealth(ctrl *gomock.Controller) *MockRepoHealth {
+	mock := &MockRepoHealth{ctrl: ctrl}
+	mock.recorder = &MockRepoHealthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoHealth) EXPECT() *MockRepoHealthMockRecorder {
+	return m.recorder
+}
+
+// MigrationVersion mocks base method.
+func (m *MockRepoHealth) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MigrationVersion", ctx)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// MigrationVersion indicates an expected call of MigrationVersion.
+func (mr *MockRepoHealthMockRecorder) MigrationVersion(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrationVersion", reflect.TypeOf((*MockRepoHealth)(nil).MigrationVersion), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockRepoHealth) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepoHealthMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepoHealth)(nil).Ping), ctx)
+}
+
+// PoolStats mocks base method.
+func (m *MockRepoHealth) PoolStats() model.PoolStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PoolStats")
+	ret0, _ := ret[0].(model.PoolStats)
+	return ret0
+}
+
+// PoolStats indicates an expected call of PoolStats.
+func (mr *MockRepoHealthMockRecorder) PoolStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PoolStats", reflect.TypeOf((*MockRepoHealth)(nil).PoolStats))
+}
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: hold.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	decimal "github.com/shopspring/decimal"
+)
+
+// MockRepoHold is a mock of RepoHold interface.
+type MockRepoHold struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoHoldMockRecorder
+}
+
+// MockRepoHoldMockRecorder is the mock recorder for MockRepoHold.
+type MockRepoHoldMockRecorder struct {
+	mock *MockRepoHold
+}
+
+// NewMockRepoHold creates a new mock instance.
+func NewMockRepoHold(ctrl *gomock.Controller) *MockRepoHold {
+	mock := &MockRepoHold{ctrl: ctrl}
+	mock.recorder = &MockRepoHoldMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoHold) EXPECT() *MockRepoHoldMockRecorder {
+	return m.recorder
+}
+
+// CaptureHold mocks base method.
+func (m *MockRepoHold) CaptureHold(ctx context.Context, UUID uuid.UUID, amount decimal.Decimal) (model.HoldCaptureResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHold", ctx, UUID, amount)
+	ret0, _ := ret[0].(model.HoldCaptureResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHold indicates an expected call of CaptureHold.
+func (mr *MockRepoHoldMockRecorder) CaptureHold(ctx, UUID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHold", reflect.TypeOf((*MockRepoHold)(nil).CaptureHold), ctx, UUID, amount)
+}
+
+// CreateHold mocks base method.
+func (m *MockRepoHold) CreateHold(ctx context.Context, request model.HoldRequest) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, request)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockRepoHoldMockRecorder) CreateHold(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockRepoHold)(nil).CreateHold), ctx, request)
+}
+
+// ExpireHolds mocks base method.
+func (m *MockRepoHold) ExpireHolds(ctx context.Context, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireHolds", ctx, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireHolds indicates an expected call of ExpireHolds.
+func (mr *MockRepoHoldMockRecorder) ExpireHolds(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireHolds", reflect.TypeOf((*MockRepoHold)(nil).ExpireHolds), ctx, limit)
+}
+
+// GetHold mocks base method.
+func (m *MockRepoHold) GetHold(ctx context.Context, UUID uuid.UUID) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHold", ctx, UUID)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHold indicates an expected call of GetHold.
+func (mr *MockRepoHoldMockRecorder) GetHold(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHold", reflect.TypeOf((*MockRepoHold)(nil).GetHold), ctx, UUID)
+}
+
+// ReleaseHold mocks base method.
+func (m *MockRepoHold) ReleaseHold(ctx context.Context, UUID uuid.UUID) (model.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseHold", ctx, UUID)
+	ret0, _ := ret[0].(model.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseHold indicates an expected call of ReleaseHold.
+func (mr *MockRepoHoldMockRecorder) ReleaseHold(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseHold", reflect.TypeOf((*MockRepoHold)(nil).ReleaseHold), ctx, UUID)
+}
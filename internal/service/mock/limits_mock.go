@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: limits.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepoLimits is a mock of RepoLimits interface.
+type MockRepoLimits struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoLimitsMockRecorder
+}
+
+// MockRepoLimitsMockRecorder is the mock recorder for MockRepoLimits.
+type MockRepoLimitsMockRecorder struct {
+	mock *MockRepoLimits
+}
+
+// NewMockRepoLimits creates a new mock instance.
+func NewMockRepoLimits(ctrl *gomock.Controller) *MockRepoLimits {
+	mock := &MockRepoLimits{ctrl: ctrl}
+	mock.recorder = &MockRepoLimitsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoLimits) EXPECT() *MockRepoLimitsMockRecorder {
+	return m.recorder
+}
+
+// GetWallet mocks base method.
+func (m *MockRepoLimits) GetWallet(ctx context.Context, UUID uuid.UUID) (model.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", ctx, UUID)
+	ret0, _ := ret[0].(model.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockRepoLimitsMockRecorder) GetWallet(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockRepoLimits)(nil).GetWallet), ctx, UUID)
+}
+
+// GetWalletLimits mocks base method.
+func (m *MockRepoLimits) GetWalletLimits(ctx context.Context, UUID uuid.UUID) (model.Limits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletLimits", ctx, UUID)
+	ret0, _ := ret[0].(model.Limits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletLimits indicates an expected call of GetWalletLimits.
+func (mr *MockRepoLimitsMockRecorder) GetWalletLimits(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletLimits", reflect.TypeOf((*MockRepoLimits)(nil).GetWalletLimits), ctx, UUID)
+}
+
+// SetWalletLimits mocks base method.
+func (m *MockRepoLimits) SetWalletLimits(ctx context.Context, UUID uuid.UUID, limits model.Limits) (model.Limits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWalletLimits", ctx, UUID, limits)
+	ret0, _ := ret[0].(model.Limits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetWalletLimits indicates an expected call of SetWalletLimits.
+func (mr *MockRepoLimitsMockRecorder) SetWalletLimits(ctx, UUID, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWalletLimits", reflect.TypeOf((*MockRepoLimits)(nil).SetWalletLimits), ctx, UUID, limits)
+}
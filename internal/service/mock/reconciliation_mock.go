@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reconciliation.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepoReconciliation is a mock of RepoReconciliation interface.
+type MockRepoReconciliation struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoReconciliationMockRecorder
+}
+
+// MockRepoReconciliationMockRecorder is the mock recorder for MockRepoReconciliation.
+type MockRepoReconciliationMockRecorder struct {
+	mock *MockRepoReconciliation
+}
+
+// NewMockRepoReconciliation creates a new mock instance.
+func NewMockRepoReconciliation(ctrl *gomock.Controller) *MockRepoReconciliation {
+	mock := &MockRepoReconciliation{ctrl: ctrl}
+	mock.recorder = &MockRepoReconciliationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoReconciliation) EXPECT() *MockRepoReconciliationMockRecorder {
+	return m.recorder
+}
+
+// ReconcileWallets mocks base method.
+func (m *MockRepoReconciliation) ReconcileWallets(ctx context.Context, after uuid.UUID, limit int) ([]model.WalletReconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileWallets", ctx, after, limit)
+	ret0, _ := ret[0].([]model.WalletReconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileWallets indicates an expected call of ReconcileWallets.
+func (mr *MockRepoReconciliationMockRecorder) ReconcileWallets(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileWallets", reflect.TypeOf((*MockRepoReconciliation)(nil).ReconcileWallets), ctx, after, limit)
+}
+
+// SaveReconciliationReport mocks base method.
+func (m *MockRepoReconciliation) SaveReconciliationReport(ctx context.Context, report model.ReconciliationReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReconciliationReport", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveReconciliationReport indicates an expected call of SaveReconciliationReport.
+func (mr *MockRepoReconciliationMockRecorder) SaveReconciliationReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReconciliationReport", reflect.TypeOf((*MockRepoReconciliation)(nil).SaveReconciliationReport), ctx, report)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/dannamer/JavaCode-test/internal/model"
 	gomock "github.com/golang/mock/gomock"
@@ -36,6 +37,81 @@ func (m *MockRepoWallet) EXPECT() *MockRepoWalletMockRecorder {
 	return m.recorder
 }
 
+// CreateWallet mocks base method.
+func (m *MockRepoWallet) CreateWallet(ctx context.Context, wallet model.Wallet) (model.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWallet", ctx, wallet)
+	ret0, _ := ret[0].(model.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWallet indicates an expected call of CreateWallet.
+func (mr *MockRepoWalletMockRecorder) CreateWallet(ctx, wallet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockRepoWallet)(nil).CreateWallet), ctx, wallet)
+}
+
+// GetBalanceAt mocks base method.
+func (m *MockRepoWallet) GetBalanceAt(ctx context.Context, UUID uuid.UUID, at time.Time) (model.HistoricalBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceAt", ctx, UUID, at)
+	ret0, _ := ret[0].(model.HistoricalBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceAt indicates an expected call of GetBalanceAt.
+func (mr *MockRepoWalletMockRecorder) GetBalanceAt(ctx, UUID, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceAt", reflect.TypeOf((*MockRepoWallet)(nil).GetBalanceAt), ctx, UUID, at)
+}
+
+// GetBalanceCheck mocks base method.
+func (m *MockRepoWallet) GetBalanceCheck(ctx context.Context, UUID uuid.UUID) (model.BalanceCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceCheck", ctx, UUID)
+	ret0, _ := ret[0].(model.BalanceCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceCheck indicates an expected call of GetBalanceCheck.
+func (mr *MockRepoWalletMockRecorder) GetBalanceCheck(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceCheck", reflect.TypeOf((*MockRepoWallet)(nil).GetBalanceCheck), ctx, UUID)
+}
+
+// GetTransaction mocks base method.
+func (m *MockRepoWallet) GetTransaction(ctx context.Context, UUID uuid.UUID) (model.TransactionRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransaction", ctx, UUID)
+	ret0, _ := ret[0].(model.TransactionRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransaction indicates an expected call of GetTransaction.
+func (mr *MockRepoWalletMockRecorder) GetTransaction(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockRepoWallet)(nil).GetTransaction), ctx, UUID)
+}
+
+// GetTransactions mocks base method.
+func (m *MockRepoWallet) GetTransactions(ctx context.Context, filter model.TransactionFilter) ([]model.TransactionRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactions", ctx, filter)
+	ret0, _ := ret[0].([]model.TransactionRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactions indicates an expected call of GetTransactions.
+func (mr *MockRepoWalletMockRecorder) GetTransactions(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockRepoWallet)(nil).GetTransactions), ctx, filter)
+}
+
 // GetWallet mocks base method.
 func (m *MockRepoWallet) GetWallet(ctx context.Context, UUID uuid.UUID) (model.Wallet, error) {
 	m.ctrl.T.Helper()
@@ -51,17 +127,47 @@ func (mr *MockRepoWalletMockRecorder) GetWallet(ctx, UUID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockRepoWallet)(nil).GetWallet), ctx, UUID)
 }
 
+// ProcessBatch mocks base method.
+func (m *MockRepoWallet) ProcessBatch(ctx context.Context, request model.BatchRequest) (model.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessBatch", ctx, request)
+	ret0, _ := ret[0].(model.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessBatch indicates an expected call of ProcessBatch.
+func (mr *MockRepoWalletMockRecorder) ProcessBatch(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBatch", reflect.TypeOf((*MockRepoWallet)(nil).ProcessBatch), ctx, request)
+}
+
 // ProcessTransaction mocks base method.
-func (m *MockRepoWallet) ProcessTransaction(ctx context.Context, wallet model.Wallet, transaction model.Transaction) (uuid.UUID, error) {
+func (m *MockRepoWallet) ProcessTransaction(ctx context.Context, transaction model.Transaction) (model.TransactionRecord, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProcessTransaction", ctx, wallet, transaction)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret := m.ctrl.Call(m, "ProcessTransaction", ctx, transaction)
+	ret0, _ := ret[0].(model.TransactionRecord)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ProcessTransaction indicates an expected call of ProcessTransaction.
-func (mr *MockRepoWalletMockRecorder) ProcessTransaction(ctx, wallet, transaction interface{}) *gomock.Call {
+func (mr *MockRepoWalletMockRecorder) ProcessTransaction(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTransaction", reflect.TypeOf((*MockRepoWallet)(nil).ProcessTransaction), ctx, transaction)
+}
+
+// ProcessTransfer mocks base method.
+func (m *MockRepoWallet) ProcessTransfer(ctx context.Context, transfer model.Transfer) (model.TransferResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessTransfer", ctx, transfer)
+	ret0, _ := ret[0].(model.TransferResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessTransfer indicates an expected call of ProcessTransfer.
+func (mr *MockRepoWalletMockRecorder) ProcessTransfer(ctx, transfer interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTransaction", reflect.TypeOf((*MockRepoWallet)(nil).ProcessTransaction), ctx, wallet, transaction)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTransfer", reflect.TypeOf((*MockRepoWallet)(nil).ProcessTransfer), ctx, transfer)
 }
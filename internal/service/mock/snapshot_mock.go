@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snapshot.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepoSnapshot is a mock of RepoSnapshot interface.
+type MockRepoSnapshot struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoSnapshotMockRecorder
+}
+
+// MockRepoSnapshotMockRecorder is the mock recorder for MockRepoSnapshot.
+type MockRepoSnapshotMockRecorder struct {
+	mock *MockRepoSnapshot
+}
+
+// NewMockRepoSnapshot creates a new mock instance.
+func NewMockRepoSnapshot(ctrl *gomock.Controller) *MockRepoSnapshot {
+	mock := &MockRepoSnapshot{ctrl: ctrl}
+	mock.recorder = &MockRepoSnapshotMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoSnapshot) EXPECT() *MockRepoSnapshotMockRecorder {
+	return m.recorder
+}
+
+// CreateBalanceSnapshots mocks base method.
+func (m *MockRepoSnapshot) CreateBalanceSnapshots(ctx context.Context, at time.Time, after uuid.UUID, limit int) (model.BalanceSnapshotBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBalanceSnapshots", ctx, at, after, limit)
+	ret0, _ := ret[0].(model.BalanceSnapshotBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBalanceSnapshots indicates an expected call of CreateBalanceSnapshots.
+func (mr *MockRepoSnapshotMockRecorder) CreateBalanceSnapshots(ctx, at, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBalanceSnapshots", reflect.TypeOf((*MockRepoSnapshot)(nil).CreateBalanceSnapshots), ctx, at, after, limit)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statement.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRepoStatement is a mock of RepoStatement interface.
+type MockRepoStatement struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoStatementMockRecorder
+}
+
+// MockRepoStatementMockRecorder is the mock recorder for MockRepoStatement.
+type MockRepoStatementMockRecorder struct {
+	mock *MockRepoStatement
+}
+
+// NewMockRepoStatement creates a new mock instance.
+func NewMockRepoStatement(ctrl *gomock.Controller) *MockRepoStatement {
+	mock := &MockRepoStatement{ctrl: ctrl}
+	mock.recorder = &MockRepoStatementMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoStatement) EXPECT() *MockRepoStatementMockRecorder {
+	return m.recorder
+}
+
+// StreamStatement mocks base method.
+func (m *MockRepoStatement) StreamStatement(ctx context.Context, period model.StatementPeriod, begin func(model.Statement) error, line func(model.TransactionRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamStatement", ctx, period, begin, line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamStatement indicates an expected call of StreamStatement.
+func (mr *MockRepoStatementMockRecorder) StreamStatement(ctx, period, begin, line interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamStatement", reflect.TypeOf((*MockRepoStatement)(nil).StreamStatement), ctx, period, begin, line)
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: wallet_status.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dannamer/JavaCode-test/internal/model"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepoWalletStatus is a mock of RepoWalletStatus interface.
+type MockRepoWalletStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoWalletStatusMockRecorder
+}
+
+// MockRepoWalletStatusMockRecorder is the mock recorder for MockRepoWalletStatus.
+type MockRepoWalletStatusMockRecorder struct {
+	mock *MockRepoWalletStatus
+}
+
+// NewMockRepoWalletStatus creates a new mock instance.
+func NewMockRepoWalletStatus(ctrl *gomock.Controller) *MockRepoWalletStatus {
+	mock := &MockRepoWalletStatus{ctrl: ctrl}
+	mock.recorder = &MockRepoWalletStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepoWalletStatus) EXPECT() *MockRepoWalletStatusMockRecorder {
+	return m.recorder
+}
+
+// GetWallet mocks base method.
+func (m *MockRepoWalletStatus) GetWallet(ctx context.Context, UUID uuid.UUID) (model.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", ctx, UUID)
+	ret0, _ := ret[0].(model.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockRepoWalletStatusMockRecorder) GetWallet(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockRepoWalletStatus)(nil).GetWallet), ctx, UUID)
+}
+
+// GetWalletStatusHistory mocks base method.
+func (m *MockRepoWalletStatus) GetWalletStatusHistory(ctx context.Context, UUID uuid.UUID) ([]model.WalletStatusAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletStatusHistory", ctx, UUID)
+	ret0, _ := ret[0].([]model.WalletStatusAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletStatusHistory indicates an expected call of GetWalletStatusHistory.
+func (mr *MockRepoWalletStatusMockRecorder) GetWalletStatusHistory(ctx, UUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletStatusHistory", reflect.TypeOf((*MockRepoWalletStatus)(nil).GetWalletStatusHistory), ctx, UUID)
+}
+
+// SetWalletStatus mocks base method.
+func (m *MockRepoWalletStatus) SetWalletStatus(ctx context.Context, change model.WalletStatusChange) (model.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWalletStatus", ctx, change)
+	ret0, _ := ret[0].(model.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetWalletStatus indicates an expected call of SetWalletStatus.
+func (mr *MockRepoWalletStatusMockRecorder) SetWalletStatus(ctx, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWalletStatus", reflect.TypeOf((*MockRepoWalletStatus)(nil).SetWalletStatus), ctx, change)
+}
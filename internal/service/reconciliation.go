@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/metrics"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=reconciliation.go -destination=mock/reconciliation_mock.go -package=mock
+type RepoReconciliation interface {
+	ReconcileWallets(ctx context.Context, after uuid.UUID, limit int) ([]model.WalletReconciliation, error)
+	SaveReconciliationReport(ctx context.Context, report model.ReconciliationReport) error
+}
+
+type ReconciliationService struct {
+	RepoReconciliation
+	log       *logrus.Logger
+	batchSize int
+}
+
+func NewReconciliationService(repo RepoReconciliation, log *logrus.Logger, batchSize int) ReconciliationService {
+	if batchSize <= 0 {
+		batchSize = model.DefaultReconciliationBatchSize
+	}
+	return ReconciliationService{RepoReconciliation: repo, log: log, batchSize: batchSize}
+}
+
+func (s *ReconciliationService) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, s.log)
+}
+
+// Reconcile обходит кошельки короткими пачками по UUID, чтобы не держать долгих запросов,
+// и сохраняет найденные расхождения.
+func (s *ReconciliationService) Reconcile(ctx context.Context) (model.ReconciliationReport, error) {
+	report := model.ReconciliationReport{
+		RunID:      uuid.New(),
+		StartedAt:  time.Now().UTC(),
+		Mismatches: []model.WalletReconciliation{},
+	}
+	log := s.logger(ctx).WithField("run_uuid", report.RunID)
+
+	after := uuid.Nil
+	for {
+		wallets, err := s.ReconcileWallets(ctx, after, s.batchSize)
+		if err != nil {
+			return model.ReconciliationReport{}, err
+		}
+		for _, wallet := range wallets {
+			if !wallet.Consistent() {
+				log.WithFields(logrus.Fields{
+					logger.FieldWalletUUID: wallet.WalletID,
+					"balance":              wallet.Balance,
+					"transactions_balance": wallet.TransactionsBalance,
+					"ledger_balance":       wallet.LedgerBalance,
+				}).Warn("Wallet balance drift detected")
+				report.Mismatches = append(report.Mismatches, wallet)
+			}
+		}
+		report.WalletsChecked += len(wallets)
+		if len(wallets) < s.batchSize {
+			break
+		}
+		after = wallets[len(wallets)-1].WalletID
+	}
+	report.FinishedAt = time.Now().UTC()
+
+	if err := s.SaveReconciliationReport(ctx, report); err != nil {
+		return model.ReconciliationReport{}, err
+	}
+	metrics.ObserveReconciliation(len(report.Mismatches))
+	log.WithFields(logrus.Fields{
+		"wallets_checked": report.WalletsChecked,
+		"mismatches":      len(report.Mismatches),
+	}).Info("Reconciliation completed")
+	return report, nil
+}
+
+// RunSchedule повторяет сверку с заданным интервалом до отмены ctx.
+func (s *ReconciliationService) RunSchedule(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Reconcile(ctx); err != nil && ctx.Err() == nil {
+				s.logger(ctx).Errorf("Scheduled reconciliation failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func reconciledWallet(balance, transactions, ledger int32) model.WalletReconciliation {
+	return model.WalletReconciliation{
+		WalletID:            uuid.New(),
+		Balance:             decimal.NewFromInt32(balance),
+		TransactionsBalance: decimal.NewFromInt32(transactions),
+		LedgerBalance:       decimal.NewFromInt32(ledger),
+	}
+}
+
+func TestReconciliationService_Reconcile_WalksBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoReconciliation(ctrl)
+	first := []model.WalletReconciliation{reconciledWallet(100, 100, 100), reconciledWallet(100, 90, 100)}
+	second := []model.WalletReconciliation{reconciledWallet(5, 5, 0)}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().ReconcileWallets(gomock.Any(), uuid.Nil, 2).Return(first, nil),
+		mockRepo.EXPECT().ReconcileWallets(gomock.Any(), first[1].WalletID, 2).Return(second, nil),
+	)
+	mockRepo.EXPECT().SaveReconciliationReport(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, report model.ReconciliationReport) error {
+			assert.Equal(t, 3, report.WalletsChecked)
+			assert.Equal(t, []model.WalletReconciliation{first[1], second[0]}, report.Mismatches)
+			return nil
+		})
+
+	reconciliation := NewReconciliationService(mockRepo, logger.Discard(), 2)
+
+	report, err := reconciliation.Reconcile(context.Background())
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, report.RunID)
+	assert.Len(t, report.Mismatches, 2)
+	assert.False(t, report.FinishedAt.Before(report.StartedAt))
+}
+
+func TestReconciliationService_Reconcile_QueryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoReconciliation(ctrl)
+	mockRepo.EXPECT().ReconcileWallets(gomock.Any(), uuid.Nil, model.DefaultReconciliationBatchSize).
+		Return(nil, errors.New("query error"))
+	mockRepo.EXPECT().SaveReconciliationReport(gomock.Any(), gomock.Any()).Times(0)
+
+	reconciliation := NewReconciliationService(mockRepo, logger.Discard(), 0)
+
+	_, err := reconciliation.Reconcile(context.Background())
+
+	assert.EqualError(t, err, "query error")
+}
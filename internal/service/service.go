@@ -2,51 +2,145 @@ package service
 
 import (
 	"context"
-	"errors"
-	"sync"
+	"time"
 
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/metrics"
 	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 )
 
 //go:generate mockgen -source=service.go -destination=mock/service_mock.go -package=mock
 type RepoWallet interface {
 	GetWallet(ctx context.Context, UUID uuid.UUID) (model.Wallet, error)
-	ProcessTransaction(ctx context.Context, wallet model.Wallet, transaction model.Transaction) (uuid.UUID, error)
+	ProcessTransaction(ctx context.Context, transaction model.Transaction) (model.TransactionRecord, error)
+	CreateWallet(ctx context.Context, wallet model.Wallet) (model.Wallet, error)
+	GetTransactions(ctx context.Context, filter model.TransactionFilter) ([]model.TransactionRecord, error)
+	ProcessTransfer(ctx context.Context, transfer model.Transfer) (model.TransferResult, error)
+	GetBalanceCheck(ctx context.Context, UUID uuid.UUID) (model.BalanceCheck, error)
+	GetTransaction(ctx context.Context, UUID uuid.UUID) (model.TransactionRecord, error)
+	ProcessBatch(ctx context.Context, request model.BatchRequest) (model.BatchResult, error)
+	GetBalanceAt(ctx context.Context, UUID uuid.UUID, at time.Time) (model.HistoricalBalance, error)
 }
 
 type WalletService struct {
 	RepoWallet
-	Mu sync.Mutex
+	log *logrus.Logger
 }
 
-func NewWalletService(repoWallet RepoWallet) WalletService {
-	return WalletService{RepoWallet: repoWallet}
+func NewWalletService(repoWallet RepoWallet, log *logrus.Logger) WalletService {
+	return WalletService{RepoWallet: repoWallet, log: log}
 }
 
-func (s *WalletService) WalletTransaction(ctx context.Context, transaction model.Transaction) error {
-	s.Mu.Lock()
-	defer s.Mu.Unlock()
-	wallet, err := s.GetWallet(ctx, transaction.WalletID)
+func (s *WalletService) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, s.log)
+}
+
+// WalletTransaction не держит блокировок в процессе: проверка статуса кошелька, средств
+// и изменение баланса выполняются репозиторием в одной транзакции БД. Замороженный
+// кошелёк отклоняет списания с ErrWalletFrozen, закрытый — любые операции с ErrWalletClosed.
+func (s *WalletService) WalletTransaction(ctx context.Context, transaction model.Transaction) (model.TransactionRecord, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletService.WalletTransaction")
+	record, err := s.ProcessTransaction(ctx, transaction)
+	tracing.End(span, err)
+	metrics.ObserveOperation(string(transaction.OperationType), err)
+	log := s.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldWalletUUID: transaction.WalletID,
+		logger.FieldOperation:  transaction.OperationType,
+	})
+	if err != nil {
+		log.WithField(logger.FieldOutcome, metrics.Outcome(err)).Info("Wallet operation rejected")
+		return model.TransactionRecord{}, err
+	}
+	log.WithFields(logrus.Fields{
+		logger.FieldTransactionUUID: record.UUID,
+		logger.FieldAmount:          transaction.Amount,
+	}).Info("Wallet operation completed")
+	return record, nil
+}
+
+// ReverseTransaction проводит отмену как обычную операцию через WalletTransaction, поэтому
+// к ней применяются те же статус кошелька, лимиты и идемпотентность. Остаток, доступный
+// для отмены, репозиторий перепроверяет под блокировкой исходной операции.
+func (s *WalletService) ReverseTransaction(ctx context.Context, reversal model.Reversal) (model.TransactionRecord, error) {
+	original, err := s.GetTransaction(ctx, reversal.TransactionID)
 	if err != nil {
-		return err
+		return model.TransactionRecord{}, err
+	}
+
+	operation, ok := model.ReversalOperation(original.OperationType)
+	if !ok {
+		return model.TransactionRecord{}, model.ErrNotReversible
+	}
+
+	amount := original.Amount
+	if reversal.Amount != nil {
+		amount = *reversal.Amount
+	}
+	if amount.GreaterThan(original.Amount) {
+		return model.TransactionRecord{}, model.ErrReversalExceedsAmount
 	}
 
-	if transaction.OperationType == model.Withdraw && wallet.Balance.LessThan(transaction.Amount) {
-		return errors.New("insufficient funds")
+	transaction := model.Transaction{
+		WalletID:       original.WalletID,
+		OperationType:  operation,
+		Amount:         amount,
+		Currency:       original.Currency,
+		IdempotencyKey: reversal.IdempotencyKey,
+		ReversalOf:     &original.UUID,
+	}
+	if !transaction.ValidateAmount() {
+		return model.TransactionRecord{}, model.ErrInvalidAmount
 	}
+	return s.WalletTransaction(ctx, transaction)
+}
 
-	switch transaction.OperationType {
-	case model.Deposit:
-		wallet.Balance = wallet.Balance.Add(transaction.Amount)
-	case model.Withdraw:
-		wallet.Balance = wallet.Balance.Sub(transaction.Amount)
+func (s *WalletService) WalletBatch(ctx context.Context, request model.BatchRequest) (model.BatchResult, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletService.WalletBatch")
+	result, err := s.ProcessBatch(ctx, request)
+	tracing.End(span, err)
+
+	log := s.logger(ctx).WithFields(logrus.Fields{
+		"batch_mode": request.BatchMode(),
+		"batch_size": len(request.Transactions),
+	})
+	if err != nil {
+		log.WithField(logger.FieldOutcome, metrics.Outcome(err)).Info("Batch rejected")
+		return model.BatchResult{}, err
 	}
+	for _, item := range result.Items {
+		if item.Status != model.BatchItemRolledBack {
+			metrics.ObserveOperation(string(request.Transactions[item.Index].OperationType), item.Err)
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"succeeded": result.Succeeded,
+		"failed":    result.Failed,
+	}).Info("Batch processed")
+	return result, nil
+}
 
-	if _, err = s.ProcessTransaction(ctx, wallet, transaction); err != nil {
-		return err
+func (s *WalletService) TransferFunds(ctx context.Context, transfer model.Transfer) (model.TransferResult, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "WalletService.TransferFunds")
+	result, err := s.ProcessTransfer(ctx, transfer)
+	tracing.End(span, err)
+	metrics.ObserveOperation(metrics.OperationTransfer, err)
+	log := s.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldOperation: metrics.OperationTransfer,
+		"from_wallet_uuid":    transfer.FromWalletID,
+		"to_wallet_uuid":      transfer.ToWalletID,
+	})
+	if err != nil {
+		log.WithField(logger.FieldOutcome, metrics.Outcome(err)).Info("Transfer rejected")
+		return model.TransferResult{}, err
 	}
-	return nil
+	log.WithFields(logrus.Fields{
+		logger.FieldTransferUUID: result.TransferID,
+		logger.FieldAmount:       transfer.Amount,
+	}).Info("Transfer completed")
+	return result, nil
 }
 
 func (s *WalletService) GetWalletBalance(ctx context.Context, UUID uuid.UUID) (model.Wallet, error) {
@@ -56,3 +150,63 @@ func (s *WalletService) GetWalletBalance(ctx context.Context, UUID uuid.UUID) (m
 	}
 	return wallet, nil
 }
+
+// WalletBalanceAt возвращает баланс на момент at; момент в будущем приводится к текущему.
+func (s *WalletService) WalletBalanceAt(ctx context.Context, UUID uuid.UUID, at time.Time) (model.HistoricalBalance, error) {
+	if now := time.Now().UTC(); at.After(now) {
+		at = now
+	}
+	return s.GetBalanceAt(ctx, UUID, at.UTC())
+}
+
+func (s *WalletService) OpenWallet(ctx context.Context, creation model.WalletCreation) (model.Wallet, error) {
+	wallet, err := s.CreateWallet(ctx, model.Wallet{
+		UUID:     creation.UUID,
+		OwnerID:  creation.OwnerID,
+		Balance:  creation.Balance,
+		Currency: creation.CurrencyCode(),
+	})
+	if err != nil {
+		return model.Wallet{}, err
+	}
+	s.logger(ctx).WithField(logger.FieldWalletUUID, wallet.UUID).Info("Wallet created")
+	return wallet, nil
+}
+
+func (s *WalletService) GetWalletTransactions(ctx context.Context, filter model.TransactionFilter) (model.TransactionList, error) {
+	if _, err := s.GetWallet(ctx, filter.WalletID); err != nil {
+		return model.TransactionList{}, err
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница.
+	limit := filter.Limit
+	filter.Limit++
+	transactions, err := s.GetTransactions(ctx, filter)
+	if err != nil {
+		return model.TransactionList{}, err
+	}
+
+	list := model.TransactionList{Transactions: transactions}
+	if len(transactions) > limit {
+		list.Transactions = transactions[:limit]
+		last := list.Transactions[limit-1]
+		list.NextCursor = model.TransactionCursor{CreatedAt: last.CreatedAt, UUID: last.UUID}.Encode()
+	}
+	return list, nil
+}
+
+// CheckWalletBalance сверяет сохранённый баланс кошелька с его проводками в главной книге.
+func (s *WalletService) CheckWalletBalance(ctx context.Context, UUID uuid.UUID) (model.BalanceCheck, error) {
+	check, err := s.GetBalanceCheck(ctx, UUID)
+	if err != nil {
+		return model.BalanceCheck{}, err
+	}
+	if !check.Consistent() {
+		s.logger(ctx).WithFields(logrus.Fields{
+			logger.FieldWalletUUID: UUID,
+			"balance":              check.Balance,
+			"ledger_balance":       check.LedgerBalance,
+		}).Error("Wallet balance does not match ledger")
+	}
+	return check, nil
+}
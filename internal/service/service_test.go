@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/metrics"
 	"github.com/dannamer/JavaCode-test/internal/model"
 	"github.com/dannamer/JavaCode-test/internal/service/mock"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,25 +23,28 @@ func TestWalletService_WalletTransaction_DepositSuccess(t *testing.T) {
 
 	mockRepo := mock.NewMockRepoWallet(ctrl)
 	walletUUID := uuid.New()
-	initialBalance := decimal.NewFromInt32(100)
 	transaction := model.Transaction{
 		WalletID:      walletUUID,
 		OperationType: model.Deposit,
 		Amount:        decimal.NewFromInt32(50),
 	}
+	balanceAfter := decimal.NewFromInt32(150)
+	expected := model.TransactionRecord{
+		UUID:          uuid.New(),
+		WalletID:      walletUUID,
+		OperationType: model.Deposit,
+		Amount:        decimal.NewFromInt32(50),
+		BalanceAfter:  &balanceAfter,
+	}
 
-	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{
-		UUID:    walletUUID,
-		Balance: initialBalance,
-	}, nil)
-
-	mockRepo.EXPECT().ProcessTransaction(context.Background(), gomock.Any(), transaction).Return(walletUUID, nil)
+	mockRepo.EXPECT().ProcessTransaction(gomock.Any(), transaction).Return(expected, nil)
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, logger.Discard())
 
-	err := walletService.WalletTransaction(context.Background(), transaction)
+	record, err := walletService.WalletTransaction(context.Background(), transaction)
 
 	assert.NoError(t, err)
+	assert.Equal(t, expected, record)
 }
 
 func TestWalletService_WalletTransaction_WithdrawSuccess(t *testing.T) {
@@ -46,25 +53,28 @@ func TestWalletService_WalletTransaction_WithdrawSuccess(t *testing.T) {
 
 	mockRepo := mock.NewMockRepoWallet(ctrl)
 	walletUUID := uuid.New()
-	initialBalance := decimal.NewFromInt32(100)
 	transaction := model.Transaction{
 		WalletID:      walletUUID,
 		OperationType: model.Withdraw,
 		Amount:        decimal.NewFromInt32(50),
 	}
+	balanceAfter := decimal.NewFromInt32(50)
+	expected := model.TransactionRecord{
+		UUID:          uuid.New(),
+		WalletID:      walletUUID,
+		OperationType: model.Withdraw,
+		Amount:        decimal.NewFromInt32(50),
+		BalanceAfter:  &balanceAfter,
+	}
 
-	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{
-		UUID:    walletUUID,
-		Balance: initialBalance,
-	}, nil)
-
-	mockRepo.EXPECT().ProcessTransaction(context.Background(), gomock.Any(), transaction).Return(walletUUID, nil)
+	mockRepo.EXPECT().ProcessTransaction(gomock.Any(), transaction).Return(expected, nil)
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, logger.Discard())
 
-	err := walletService.WalletTransaction(context.Background(), transaction)
+	record, err := walletService.WalletTransaction(context.Background(), transaction)
 
 	assert.NoError(t, err)
+	assert.Equal(t, expected, record)
 }
 
 func TestWalletService_WalletTransaction_WithdrawInsufficientFunds(t *testing.T) {
@@ -73,26 +83,22 @@ func TestWalletService_WalletTransaction_WithdrawInsufficientFunds(t *testing.T)
 
 	mockRepo := mock.NewMockRepoWallet(ctrl)
 	walletUUID := uuid.New()
-	initialBalance := decimal.NewFromInt32(30)
 	transaction := model.Transaction{
 		WalletID:      walletUUID,
 		OperationType: model.Withdraw,
 		Amount:        decimal.NewFromInt32(50),
 	}
 
-	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{
-		UUID:    walletUUID,
-		Balance: initialBalance,
-	}, nil)
+	mockRepo.EXPECT().ProcessTransaction(gomock.Any(), transaction).Return(model.TransactionRecord{}, model.ErrInsufficientFunds)
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, logger.Discard())
 
-	err := walletService.WalletTransaction(context.Background(), transaction)
+	_, err := walletService.WalletTransaction(context.Background(), transaction)
 
-	assert.EqualError(t, err, "insufficient funds")
+	assert.ErrorIs(t, err, model.ErrInsufficientFunds)
 }
 
-func TestWalletService_WalletTransaction_GetWalletError(t *testing.T) {
+func TestWalletService_WalletTransaction_WalletNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -104,13 +110,13 @@ func TestWalletService_WalletTransaction_GetWalletError(t *testing.T) {
 		Amount:        decimal.NewFromInt32(50),
 	}
 
-	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{}, errors.New("no rows in result set"))
+	mockRepo.EXPECT().ProcessTransaction(gomock.Any(), transaction).Return(model.TransactionRecord{}, model.NewWalletNotFoundError(walletUUID))
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, logger.Discard())
 
-	err := walletService.WalletTransaction(context.Background(), transaction)
+	_, err := walletService.WalletTransaction(context.Background(), transaction)
 
-	assert.EqualError(t, err, "no rows in result set")
+	assert.ErrorIs(t, err, model.ErrWalletNotFound)
 }
 
 func TestWalletService_WalletTransaction_ProcessTransactionError(t *testing.T) {
@@ -119,27 +125,133 @@ func TestWalletService_WalletTransaction_ProcessTransactionError(t *testing.T) {
 
 	mockRepo := mock.NewMockRepoWallet(ctrl)
 	walletUUID := uuid.New()
-	initialBalance := decimal.NewFromInt32(100)
 	transaction := model.Transaction{
 		WalletID:      walletUUID,
 		OperationType: model.Deposit,
 		Amount:        decimal.NewFromInt32(50),
 	}
 
-	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{
-		UUID:    walletUUID,
-		Balance: initialBalance,
-	}, nil)
-
-	mockRepo.EXPECT().ProcessTransaction(context.Background(), gomock.Any(), transaction).Return(uuid.Nil, errors.New("process error"))
+	mockRepo.EXPECT().ProcessTransaction(gomock.Any(), transaction).Return(model.TransactionRecord{}, errors.New("process error"))
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, logger.Discard())
 
-	err := walletService.WalletTransaction(context.Background(), transaction)
+	_, err := walletService.WalletTransaction(context.Background(), transaction)
 
 	assert.EqualError(t, err, "process error")
 }
 
+// operationCount читает счётчик wallet_operations_total из реестра по умолчанию.
+func operationCount(t *testing.T, operation model.OperationType, outcome string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != "wallet_operations_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["operation"] == string(operation) && labels["outcome"] == outcome {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestWalletService_WalletBatch_AtomicRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	walletUUID := uuid.New()
+	request := model.BatchRequest{Transactions: []model.Transaction{
+		{WalletID: walletUUID, OperationType: model.Deposit, Amount: decimal.NewFromInt32(10)},
+		{WalletID: walletUUID, OperationType: model.Withdraw, Amount: decimal.NewFromInt32(500)},
+	}}
+	rejected := model.BatchResult{
+		Mode:   model.BatchAtomic,
+		Failed: 1,
+		Items: []model.BatchItemResult{
+			{Index: 0, Status: model.BatchItemRolledBack},
+			{Index: 1, Status: model.BatchItemFailed, Err: model.ErrInsufficientFunds},
+		},
+	}
+
+	mockRepo.EXPECT().ProcessBatch(gomock.Any(), request).Return(rejected, nil)
+
+	deposits := operationCount(t, model.Deposit, metrics.OutcomeSuccess)
+	withdrawals := operationCount(t, model.Withdraw, metrics.OutcomeInsufficientFunds)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	result, err := walletService.WalletBatch(context.Background(), request)
+
+	assert.NoError(t, err)
+	item, ok := result.Rejected()
+	assert.True(t, ok)
+	assert.Equal(t, 1, item.Index)
+	assert.ErrorIs(t, item.Err, model.ErrInsufficientFunds)
+	// Отменённое зачисление не проводилось и в метриках не учитывается.
+	assert.Equal(t, deposits, operationCount(t, model.Deposit, metrics.OutcomeSuccess))
+	assert.Equal(t, withdrawals+1, operationCount(t, model.Withdraw, metrics.OutcomeInsufficientFunds))
+}
+
+func TestWalletService_WalletBatch_BestEffortObservesEveryItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	walletUUID := uuid.New()
+	request := model.BatchRequest{Mode: model.BatchBestEffort, Transactions: []model.Transaction{
+		{WalletID: walletUUID, OperationType: model.Deposit, Amount: decimal.NewFromInt32(10)},
+		{WalletID: walletUUID, OperationType: model.Withdraw, Amount: decimal.NewFromInt32(500)},
+	}}
+	processed := model.BatchResult{
+		Mode:      model.BatchBestEffort,
+		Succeeded: 1,
+		Failed:    1,
+		Items: []model.BatchItemResult{
+			{Index: 0, Status: model.BatchItemSucceeded, Transaction: &model.TransactionRecord{UUID: uuid.New()}},
+			{Index: 1, Status: model.BatchItemFailed, Err: model.ErrInsufficientFunds},
+		},
+	}
+
+	mockRepo.EXPECT().ProcessBatch(gomock.Any(), request).Return(processed, nil)
+
+	deposits := operationCount(t, model.Deposit, metrics.OutcomeSuccess)
+	withdrawals := operationCount(t, model.Withdraw, metrics.OutcomeInsufficientFunds)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	result, err := walletService.WalletBatch(context.Background(), request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, processed, result)
+	assert.Equal(t, deposits+1, operationCount(t, model.Deposit, metrics.OutcomeSuccess))
+	assert.Equal(t, withdrawals+1, operationCount(t, model.Withdraw, metrics.OutcomeInsufficientFunds))
+}
+
+func TestWalletService_WalletBatch_ProcessBatchError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	request := model.BatchRequest{Transactions: []model.Transaction{
+		{WalletID: uuid.New(), OperationType: model.Deposit, Amount: decimal.NewFromInt32(10)},
+	}}
+
+	mockRepo.EXPECT().ProcessBatch(gomock.Any(), request).Return(model.BatchResult{}, errors.New("batch error"))
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	_, err := walletService.WalletBatch(context.Background(), request)
+
+	assert.EqualError(t, err, "batch error")
+}
+
 func TestWalletService_GetWalletBalance_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -153,7 +265,7 @@ func TestWalletService_GetWalletBalance_Success(t *testing.T) {
 
 	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(expectedWallet, nil)
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, logger.Discard())
 
 	wallet, err := walletService.GetWalletBalance(context.Background(), walletUUID)
 
@@ -168,11 +280,282 @@ func TestWalletService_GetWalletBalance_Error(t *testing.T) {
 	mockRepo := mock.NewMockRepoWallet(ctrl)
 	walletUUID := uuid.New()
 
-	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{}, errors.New("no rows in result set"))
+	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{}, model.NewWalletNotFoundError(walletUUID))
 
-	walletService := NewWalletService(mockRepo)
+	walletService := NewWalletService(mockRepo, logger.Discard())
 
 	_, err := walletService.GetWalletBalance(context.Background(), walletUUID)
 
-	assert.EqualError(t, err, "no rows in result set")
+	assert.ErrorIs(t, err, model.ErrWalletNotFound)
+}
+
+func TestWalletService_OpenWallet_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	walletUUID := uuid.New()
+	ownerID := "customer-42"
+	creation := model.WalletCreation{
+		UUID:    walletUUID,
+		OwnerID: &ownerID,
+		Balance: decimal.NewFromInt32(100),
+	}
+	expectedWallet := model.Wallet{
+		UUID:     walletUUID,
+		OwnerID:  &ownerID,
+		Balance:  decimal.NewFromInt32(100),
+		Currency: model.DefaultCurrency,
+	}
+
+	mockRepo.EXPECT().CreateWallet(context.Background(), model.Wallet{
+		UUID:     walletUUID,
+		OwnerID:  &ownerID,
+		Balance:  decimal.NewFromInt32(100),
+		Currency: model.DefaultCurrency,
+	}).Return(expectedWallet, nil)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	wallet, err := walletService.OpenWallet(context.Background(), creation)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedWallet, wallet)
+}
+
+func TestWalletService_OpenWallet_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	creation := model.WalletCreation{UUID: uuid.New()}
+
+	mockRepo.EXPECT().CreateWallet(context.Background(), gomock.Any()).Return(model.Wallet{}, model.ErrWalletAlreadyExists)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	_, err := walletService.OpenWallet(context.Background(), creation)
+
+	assert.ErrorIs(t, err, model.ErrWalletAlreadyExists)
+}
+
+func TestWalletService_GetWalletTransactions_NextCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	walletUUID := uuid.New()
+	filter := model.TransactionFilter{
+		WalletID: walletUUID,
+		Order:    model.SortDesc,
+		Limit:    2,
+	}
+	now := time.Now().UTC()
+	records := []model.TransactionRecord{
+		{UUID: uuid.New(), WalletID: walletUUID, OperationType: model.Deposit, Amount: decimal.NewFromInt32(30), CreatedAt: now},
+		{UUID: uuid.New(), WalletID: walletUUID, OperationType: model.Withdraw, Amount: decimal.NewFromInt32(20), CreatedAt: now.Add(-time.Minute)},
+		{UUID: uuid.New(), WalletID: walletUUID, OperationType: model.Deposit, Amount: decimal.NewFromInt32(10), CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{UUID: walletUUID}, nil)
+	expectedFilter := filter
+	expectedFilter.Limit = 3
+	mockRepo.EXPECT().GetTransactions(context.Background(), expectedFilter).Return(records, nil)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	list, err := walletService.GetWalletTransactions(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, records[:2], list.Transactions)
+
+	cursor, err := model.ParseTransactionCursor(list.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, records[1].UUID, cursor.UUID)
+	assert.True(t, records[1].CreatedAt.Equal(cursor.CreatedAt))
+}
+
+func TestWalletService_GetWalletTransactions_LastPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	walletUUID := uuid.New()
+	filter := model.TransactionFilter{
+		WalletID: walletUUID,
+		Order:    model.SortAsc,
+		Limit:    2,
+	}
+	records := []model.TransactionRecord{
+		{UUID: uuid.New(), WalletID: walletUUID, OperationType: model.Deposit, Amount: decimal.NewFromInt32(30)},
+	}
+
+	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{UUID: walletUUID}, nil)
+	mockRepo.EXPECT().GetTransactions(context.Background(), gomock.Any()).Return(records, nil)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	list, err := walletService.GetWalletTransactions(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, records, list.Transactions)
+	assert.Empty(t, list.NextCursor)
+}
+
+func TestWalletService_GetWalletTransactions_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	walletUUID := uuid.New()
+
+	mockRepo.EXPECT().GetWallet(context.Background(), walletUUID).Return(model.Wallet{}, model.NewWalletNotFoundError(walletUUID))
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	_, err := walletService.GetWalletTransactions(context.Background(), model.TransactionFilter{WalletID: walletUUID, Limit: 10})
+
+	assert.ErrorIs(t, err, model.ErrWalletNotFound)
+}
+
+func TestWalletService_TransferFunds_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	transfer := model.Transfer{
+		FromWalletID: uuid.New(),
+		ToWalletID:   uuid.New(),
+		Amount:       decimal.NewFromInt32(25),
+	}
+	expected := model.TransferResult{
+		TransferID:        uuid.New(),
+		FromTransactionID: uuid.New(),
+		ToTransactionID:   uuid.New(),
+	}
+
+	mockRepo.EXPECT().ProcessTransfer(gomock.Any(), transfer).Return(expected, nil)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	result, err := walletService.TransferFunds(context.Background(), transfer)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestWalletService_TransferFunds_InsufficientFunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	transfer := model.Transfer{
+		FromWalletID: uuid.New(),
+		ToWalletID:   uuid.New(),
+		Amount:       decimal.NewFromInt32(25),
+	}
+
+	mockRepo.EXPECT().ProcessTransfer(gomock.Any(), transfer).Return(model.TransferResult{}, model.ErrInsufficientFunds)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	_, err := walletService.TransferFunds(context.Background(), transfer)
+
+	assert.ErrorIs(t, err, model.ErrInsufficientFunds)
+}
+
+func TestWalletService_CheckWalletBalance_Mismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	walletUUID := uuid.New()
+	expected := model.BalanceCheck{
+		WalletID:      walletUUID,
+		Balance:       decimal.NewFromInt32(100),
+		LedgerBalance: decimal.NewFromInt32(90),
+	}
+
+	mockRepo.EXPECT().GetBalanceCheck(context.Background(), walletUUID).Return(expected, nil)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	check, err := walletService.CheckWalletBalance(context.Background(), walletUUID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, check)
+	assert.False(t, check.Consistent())
+}
+
+func TestWalletService_ReverseTransaction_PartialRefundOfWithdrawal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	original := model.TransactionRecord{
+		UUID:          uuid.New(),
+		WalletID:      uuid.New(),
+		OperationType: model.Withdraw,
+		Amount:        decimal.NewFromInt32(100),
+		Currency:      model.DefaultCurrency,
+	}
+	amount := decimal.NewFromInt32(40)
+
+	mockRepo.EXPECT().GetTransaction(gomock.Any(), original.UUID).Return(original, nil)
+	mockRepo.EXPECT().ProcessTransaction(gomock.Any(), model.Transaction{
+		WalletID:       original.WalletID,
+		OperationType:  model.ReversalIn,
+		Amount:         amount,
+		Currency:       model.DefaultCurrency,
+		IdempotencyKey: "refund-1",
+		ReversalOf:     &original.UUID,
+	}).Return(model.TransactionRecord{ReversalOf: &original.UUID}, nil)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	record, err := walletService.ReverseTransaction(context.Background(), model.Reversal{
+		TransactionID:  original.UUID,
+		Amount:         &amount,
+		IdempotencyKey: "refund-1",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, original.UUID, *record.ReversalOf)
+}
+
+func TestWalletService_ReverseTransaction_TransferNotReversible(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	original := model.TransactionRecord{UUID: uuid.New(), OperationType: model.TransferOut, Amount: decimal.NewFromInt32(100)}
+
+	mockRepo.EXPECT().GetTransaction(gomock.Any(), original.UUID).Return(original, nil)
+	mockRepo.EXPECT().ProcessTransaction(gomock.Any(), gomock.Any()).Times(0)
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	_, err := walletService.ReverseTransaction(context.Background(), model.Reversal{TransactionID: original.UUID})
+
+	assert.ErrorIs(t, err, model.ErrNotReversible)
+}
+
+func TestWalletService_WalletBalanceAt_FutureClampedToNow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWallet(ctrl)
+	walletUUID := uuid.New()
+
+	mockRepo.EXPECT().GetBalanceAt(gomock.Any(), walletUUID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, at time.Time) (model.HistoricalBalance, error) {
+			assert.WithinDuration(t, time.Now(), at, time.Second)
+			return model.HistoricalBalance{WalletID: walletUUID, At: at}, nil
+		})
+
+	walletService := NewWalletService(mockRepo, logger.Discard())
+
+	_, err := walletService.WalletBalanceAt(context.Background(), walletUUID, time.Now().Add(24*time.Hour))
+
+	assert.NoError(t, err)
 }
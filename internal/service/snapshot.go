@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=snapshot.go -destination=mock/snapshot_mock.go -package=mock
+type RepoSnapshot interface {
+	CreateBalanceSnapshots(ctx context.Context, at time.Time, after uuid.UUID, limit int) (model.BalanceSnapshotBatch, error)
+}
+
+type SnapshotService struct {
+	RepoSnapshot
+	log       *logrus.Logger
+	batchSize int
+}
+
+func NewSnapshotService(repo RepoSnapshot, log *logrus.Logger, batchSize int) SnapshotService {
+	if batchSize <= 0 {
+		batchSize = model.DefaultSnapshotBatchSize
+	}
+	return SnapshotService{RepoSnapshot: repo, log: log, batchSize: batchSize}
+}
+
+func (s *SnapshotService) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, s.log)
+}
+
+// TakeSnapshots снимает балансы всех кошельков на момент at пачками по UUID и возвращает
+// число новых снимков. Уже снятые снимки пропускаются, поэтому прерванный обход можно повторить.
+func (s *SnapshotService) TakeSnapshots(ctx context.Context, at time.Time) (int64, error) {
+	var created int64
+	after := uuid.Nil
+	for {
+		batch, err := s.CreateBalanceSnapshots(ctx, at, after, s.batchSize)
+		if err != nil {
+			return created, err
+		}
+		created += batch.Created
+		if batch.LastWalletID == uuid.Nil {
+			break
+		}
+		after = batch.LastWalletID
+	}
+
+	s.logger(ctx).WithFields(logrus.Fields{
+		"snapshot_at": at,
+		"created":     created,
+	}).Info("Balance snapshots taken")
+	return created, nil
+}
+
+// RunSchedule сразу и затем с заданным интервалом снимает балансы на последнюю полночь UTC
+// до отмены ctx. Интервал короче суток лишь раньше подхватывает новую полночь.
+func (s *SnapshotService) RunSchedule(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.TakeSnapshots(ctx, model.SnapshotBoundary(time.Now())); err != nil && ctx.Err() == nil {
+			s.logger(ctx).Errorf("Scheduled balance snapshot failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
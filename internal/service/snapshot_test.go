@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotService_TakeSnapshots_WalksBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoSnapshot(ctrl)
+	at := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	first, second := uuid.New(), uuid.New()
+
+	gomock.InOrder(
+		mockRepo.EXPECT().CreateBalanceSnapshots(gomock.Any(), at, uuid.Nil, 2).
+			Return(model.BalanceSnapshotBatch{LastWalletID: first, Created: 2}, nil),
+		mockRepo.EXPECT().CreateBalanceSnapshots(gomock.Any(), at, first, 2).
+			Return(model.BalanceSnapshotBatch{LastWalletID: second, Created: 1}, nil),
+		mockRepo.EXPECT().CreateBalanceSnapshots(gomock.Any(), at, second, 2).
+			Return(model.BalanceSnapshotBatch{}, nil),
+	)
+
+	snapshots := NewSnapshotService(mockRepo, logger.Discard(), 2)
+
+	created, err := snapshots.TakeSnapshots(context.Background(), at)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), created)
+}
+
+func TestSnapshotService_TakeSnapshots_QueryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoSnapshot(ctrl)
+	mockRepo.EXPECT().CreateBalanceSnapshots(gomock.Any(), gomock.Any(), uuid.Nil, model.DefaultSnapshotBatchSize).
+		Return(model.BalanceSnapshotBatch{}, errors.New("query error"))
+
+	snapshots := NewSnapshotService(mockRepo, logger.Discard(), 0)
+
+	_, err := snapshots.TakeSnapshots(context.Background(), time.Now())
+
+	assert.EqualError(t, err, "query error")
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/statement"
+	"github.com/dannamer/JavaCode-test/internal/tracing"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=statement.go -destination=mock/statement_mock.go -package=mock
+type RepoStatement interface {
+	StreamStatement(ctx context.Context, period model.StatementPeriod,
+		begin func(model.Statement) error, line func(model.TransactionRecord) error) error
+}
+
+type StatementService struct {
+	RepoStatement
+	log *logrus.Logger
+}
+
+func NewStatementService(repo RepoStatement, log *logrus.Logger) StatementService {
+	return StatementService{RepoStatement: repo, log: log}
+}
+
+func (s *StatementService) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, s.log)
+}
+
+// WriteStatement выводит выписку в w в формате period.Format. Баланс после каждой операции
+// восстанавливается от входящего остатка, поэтому не зависит от balance_after, которого нет
+// у операций, проведённых до его появления.
+func (s *StatementService) WriteStatement(ctx context.Context, period model.StatementPeriod, w io.Writer) error {
+	ctx, span := tracing.Tracer().Start(ctx, "StatementService.WriteStatement")
+	writer := statement.NewWriter(period.Format, w)
+
+	var totals model.Statement
+	var balance decimal.Decimal
+	err := s.StreamStatement(ctx, period,
+		func(header model.Statement) error {
+			totals, balance = header, header.OpeningBalance
+			return writer.Begin(header)
+		},
+		func(record model.TransactionRecord) error {
+			balance = balance.Add(record.SignedAmount())
+			return writer.Line(model.StatementLine{TransactionRecord: record, Balance: balance})
+		})
+	if err == nil {
+		err = writer.End(totals)
+	}
+	tracing.End(span, err)
+
+	log := s.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldWalletUUID: period.WalletID,
+		"format":               period.Format,
+	})
+	if err != nil {
+		log.Errorf("Failed to write statement: %v", err)
+		return err
+	}
+	log.WithField("transactions", totals.TransactionCount).Info("Statement generated")
+	return nil
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementService_WriteStatement_RunningBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoStatement(ctrl)
+	period := model.StatementPeriod{WalletID: uuid.New(), From: time.Now().Add(-time.Hour), To: time.Now(), Format: model.StatementCSV}
+
+	mockRepo.EXPECT().StreamStatement(gomock.Any(), period, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ model.StatementPeriod, begin func(model.Statement) error, line func(model.TransactionRecord) error) error {
+			err := begin(model.Statement{WalletID: period.WalletID, Currency: "RUB",
+				OpeningBalance: decimal.NewFromInt32(100), ClosingBalance: decimal.NewFromInt32(120)})
+			assert.NoError(t, err)
+			assert.NoError(t, line(model.TransactionRecord{OperationType: model.Deposit, Amount: decimal.NewFromInt32(50), Currency: "RUB"}))
+			assert.NoError(t, line(model.TransactionRecord{OperationType: model.TransferOut, Amount: decimal.NewFromInt32(30), Currency: "RUB"}))
+			return nil
+		})
+
+	statementService := NewStatementService(mockRepo, logger.Discard())
+
+	var out bytes.Buffer
+	err := statementService.WriteStatement(context.Background(), period, &out)
+	assert.NoError(t, err)
+
+	records, err := csv.NewReader(&out).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 5)
+	assert.Equal(t, []string{"50.00", "150.00"}, records[2][3:5])
+	assert.Equal(t, []string{"-30.00", "120.00"}, records[3][3:5])
+	assert.Equal(t, "120.00", records[4][4])
+}
+
+func TestStatementService_WriteStatement_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoStatement(ctrl)
+	period := model.StatementPeriod{WalletID: uuid.New(), From: time.Now().Add(-time.Hour), To: time.Now(), Format: model.StatementPDF}
+
+	mockRepo.EXPECT().StreamStatement(gomock.Any(), period, gomock.Any(), gomock.Any()).
+		Return(model.NewWalletNotFoundError(period.WalletID))
+
+	statementService := NewStatementService(mockRepo, logger.Discard())
+
+	var out bytes.Buffer
+	err := statementService.WriteStatement(context.Background(), period, &out)
+
+	assert.ErrorIs(t, err, model.ErrWalletNotFound)
+	assert.Zero(t, out.Len())
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=wallet_status.go -destination=mock/wallet_status_mock.go -package=mock
+type RepoWalletStatus interface {
+	GetWallet(ctx context.Context, UUID uuid.UUID) (model.Wallet, error)
+	SetWalletStatus(ctx context.Context, change model.WalletStatusChange) (model.Wallet, error)
+	GetWalletStatusHistory(ctx context.Context, UUID uuid.UUID) ([]model.WalletStatusAudit, error)
+}
+
+type WalletStatusService struct {
+	RepoWalletStatus
+	log *logrus.Logger
+}
+
+func NewWalletStatusService(repo RepoWalletStatus, log *logrus.Logger) WalletStatusService {
+	return WalletStatusService{RepoWalletStatus: repo, log: log}
+}
+
+func (s *WalletStatusService) logger(ctx context.Context) *logrus.Entry {
+	return logger.FromContext(ctx, s.log)
+}
+
+func (s *WalletStatusService) ChangeWalletStatus(ctx context.Context, change model.WalletStatusChange) (model.Wallet, error) {
+	wallet, err := s.SetWalletStatus(ctx, change)
+	if err != nil {
+		return model.Wallet{}, err
+	}
+	s.logger(ctx).WithFields(logrus.Fields{
+		logger.FieldWalletUUID: change.WalletID,
+		"status":               change.Status,
+		"actor":                change.Actor,
+	}).Info("Wallet status changed")
+	return wallet, nil
+}
+
+func (s *WalletStatusService) WalletStatusHistory(ctx context.Context, UUID uuid.UUID) ([]model.WalletStatusAudit, error) {
+	if _, err := s.GetWallet(ctx, UUID); err != nil {
+		return nil, err
+	}
+	return s.GetWalletStatusHistory(ctx, UUID)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dannamer/JavaCode-test/internal/logger"
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/dannamer/JavaCode-test/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalletStatusService_ChangeWalletStatus_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWalletStatus(ctrl)
+	change := model.WalletStatusChange{WalletID: uuid.New(), Status: model.WalletFrozen, Reason: "fraud check", Actor: "support"}
+	expected := model.Wallet{UUID: change.WalletID, Status: model.WalletFrozen}
+
+	mockRepo.EXPECT().SetWalletStatus(gomock.Any(), change).Return(expected, nil)
+
+	statusService := NewWalletStatusService(mockRepo, logger.Discard())
+
+	wallet, err := statusService.ChangeWalletStatus(context.Background(), change)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, wallet)
+}
+
+func TestWalletStatusService_ChangeWalletStatus_Rejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		status model.WalletStatus
+		err    error
+	}{
+		{"invalid transition", model.WalletActive, model.ErrInvalidStatusChange},
+		{"wallet not empty", model.WalletClosed, model.ErrWalletNotEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockRepoWalletStatus(ctrl)
+			change := model.WalletStatusChange{WalletID: uuid.New(), Status: tt.status, Reason: "request", Actor: "support"}
+
+			mockRepo.EXPECT().SetWalletStatus(gomock.Any(), change).Return(model.Wallet{}, tt.err)
+
+			statusService := NewWalletStatusService(mockRepo, logger.Discard())
+
+			_, err := statusService.ChangeWalletStatus(context.Background(), change)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestWalletStatusService_WalletStatusHistory_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWalletStatus(ctrl)
+	walletUUID := uuid.New()
+	history := []model.WalletStatusAudit{
+		{ID: 1, WalletID: walletUUID, FromStatus: model.WalletActive, ToStatus: model.WalletFrozen, Reason: "fraud check", Actor: "support"},
+	}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetWallet(gomock.Any(), walletUUID).Return(model.Wallet{UUID: walletUUID}, nil),
+		mockRepo.EXPECT().GetWalletStatusHistory(gomock.Any(), walletUUID).Return(history, nil),
+	)
+
+	statusService := NewWalletStatusService(mockRepo, logger.Discard())
+
+	result, err := statusService.WalletStatusHistory(context.Background(), walletUUID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, history, result)
+}
+
+func TestWalletStatusService_WalletStatusHistory_WalletNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepoWalletStatus(ctrl)
+	walletUUID := uuid.New()
+
+	mockRepo.EXPECT().GetWallet(gomock.Any(), walletUUID).Return(model.Wallet{}, model.NewWalletNotFoundError(walletUUID))
+	mockRepo.EXPECT().GetWalletStatusHistory(gomock.Any(), gomock.Any()).Times(0)
+
+	statusService := NewWalletStatusService(mockRepo, logger.Discard())
+
+	history, err := statusService.WalletStatusHistory(context.Background(), walletUUID)
+
+	assert.ErrorIs(t, err, model.ErrWalletNotFound)
+	assert.Nil(t, history)
+}
@@ -0,0 +1,49 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+)
+
+// Типы служебных строк CSV-выписки с остатками на начало и конец периода.
+const (
+	openingBalanceRow = "OPENING_BALANCE"
+	closingBalanceRow = "CLOSING_BALANCE"
+)
+
+// csvWriter выводит выписку одной таблицей: строка входящего остатка, операции со знаковой
+// суммой и балансом после каждой и строка исходящего остатка.
+type csvWriter struct {
+	out *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{out: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) Begin(statement model.Statement) error {
+	w.out.Write([]string{"date", "type", "transaction_uuid", "amount", "balance", "currency", "reference"})
+	return w.out.Write([]string{formatTime(statement.From), openingBalanceRow, "", "",
+		formatAmount(statement.OpeningBalance, statement.Currency), statement.Currency, ""})
+}
+
+func (w *csvWriter) Line(line model.StatementLine) error {
+	return w.out.Write([]string{
+		formatTime(line.CreatedAt),
+		string(line.OperationType),
+		line.UUID.String(),
+		formatAmount(line.SignedAmount(), line.Currency),
+		formatAmount(line.Balance, line.Currency),
+		line.Currency,
+		reference(line.TransactionRecord),
+	})
+}
+
+func (w *csvWriter) End(statement model.Statement) error {
+	w.out.Write([]string{formatTime(statement.To), closingBalanceRow, "", "",
+		formatAmount(statement.ClosingBalance, statement.Currency), statement.Currency, ""})
+	w.out.Flush()
+	return w.out.Error()
+}
@@ -0,0 +1,49 @@
+package statement
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+)
+
+// jsonWriter выводит объект {"statement": {...}, "transactions": [...]}, дописывая массив
+// операций по одной.
+type jsonWriter struct {
+	out   *bufio.Writer
+	lines int64
+}
+
+func newJSONWriter(w io.Writer) *jsonWriter {
+	return &jsonWriter{out: bufio.NewWriter(w)}
+}
+
+func (w *jsonWriter) Begin(statement model.Statement) error {
+	header, err := json.Marshal(statement)
+	if err != nil {
+		return err
+	}
+	w.out.WriteString(`{"statement":`)
+	w.out.Write(header)
+	_, err = w.out.WriteString(`,"transactions":[`)
+	return err
+}
+
+func (w *jsonWriter) Line(line model.StatementLine) error {
+	encoded, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	if w.lines > 0 {
+		w.out.WriteByte(',')
+	}
+	w.lines++
+	_, err = w.out.Write(encoded)
+	return err
+}
+
+func (w *jsonWriter) End(model.Statement) error {
+	w.out.WriteString("]}\n")
+	return w.out.Flush()
+}
@@ -0,0 +1,191 @@
+package statement
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+)
+
+// Страница A4 в пунктах и сетка текста моноширинным шрифтом размера pdfFontSize.
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 36
+	pdfFontSize     = 8
+	pdfLeading      = 11
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// Номера объектов, известные заранее; страницы нумеруются следом за ними.
+const (
+	pdfCatalogObject = iota + 1
+	pdfPagesObject
+	pdfFontObject
+	pdfBoldFontObject
+	pdfFirstFreeObject
+)
+
+var pdfTableHeader = pdfRow("Date (UTC)", "Type", "Transaction", "Amount", "Balance")
+
+type pdfLine struct {
+	text string
+	bold bool
+}
+
+// pdfWriter формирует PDF без внешних зависимостей: текст выводится стандартным шрифтом
+// Courier, поэтому колонки выравниваются пробелами. В памяти держится только текущая
+// страница и смещения объектов для таблицы xref; готовые страницы сразу уходят в w.
+// Стандартный шрифт покрывает только ASCII, остальные символы заменяются на "?".
+type pdfWriter struct {
+	out       *bufio.Writer
+	written   int64
+	offsets   map[int]int64
+	next      int
+	pages     []int
+	lines     []pdfLine
+	currency  string
+	tableOpen bool
+}
+
+func newPDFWriter(w io.Writer) *pdfWriter {
+	return &pdfWriter{out: bufio.NewWriter(w), offsets: make(map[int]int64), next: pdfFirstFreeObject}
+}
+
+func (w *pdfWriter) Begin(statement model.Statement) error {
+	w.currency = statement.Currency
+	w.write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	w.object(pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject))
+	w.object(pdfFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	w.object(pdfBoldFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+
+	w.add("Account statement", true)
+	w.add("", false)
+	w.add("Wallet:          "+statement.WalletID.String(), false)
+	if statement.OwnerID != nil {
+		w.add("Owner:           "+*statement.OwnerID, false)
+	}
+	w.add("Currency:        "+statement.Currency, false)
+	w.add("Period:          "+formatTime(statement.From)+" - "+formatTime(statement.To)+" UTC", false)
+	w.add("Generated:       "+formatTime(statement.GeneratedAt)+" UTC", false)
+	w.add("", false)
+	w.add("Opening balance: "+formatAmount(statement.OpeningBalance, statement.Currency), true)
+	w.add("", false)
+	w.tableOpen = true
+	w.add(pdfTableHeader, true)
+	return w.flush()
+}
+
+func (w *pdfWriter) Line(line model.StatementLine) error {
+	w.add(pdfRow(formatTime(line.CreatedAt), string(line.OperationType), line.UUID.String(),
+		formatAmount(line.SignedAmount(), w.currency), formatAmount(line.Balance, w.currency)), false)
+	return w.flush()
+}
+
+func (w *pdfWriter) End(statement model.Statement) error {
+	w.tableOpen = false
+	w.add("", false)
+	w.add(fmt.Sprintf("Transactions:    %d", statement.TransactionCount), false)
+	w.add("Total credits:   "+formatAmount(statement.TotalCredits, statement.Currency), false)
+	w.add("Total debits:    "+formatAmount(statement.TotalDebits, statement.Currency), false)
+	w.add("Closing balance: "+formatAmount(statement.ClosingBalance, statement.Currency), true)
+	w.writePage()
+
+	kids := make([]string, 0, len(w.pages))
+	for _, page := range w.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+	}
+	w.object(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+
+	xref := w.written
+	w.write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", w.next))
+	for object := 1; object < w.next; object++ {
+		w.write(fmt.Sprintf("%010d 00000 n \n", w.offsets[object]))
+	}
+	w.write(fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", w.next, pdfCatalogObject, xref))
+	return w.out.Flush()
+}
+
+// add добавляет строку на текущую страницу, начиная новую при переполнении;
+// на каждой новой странице таблицы повторяется её заголовок.
+func (w *pdfWriter) add(text string, bold bool) {
+	if len(w.lines) == pdfLinesPerPage {
+		w.writePage()
+		if w.tableOpen {
+			w.lines = append(w.lines, pdfLine{text: pdfTableHeader, bold: true})
+		}
+	}
+	w.lines = append(w.lines, pdfLine{text: text, bold: bold})
+}
+
+// flush отправляет клиенту накопленные целые страницы.
+func (w *pdfWriter) flush() error {
+	if len(w.pages) == 0 {
+		return nil
+	}
+	return w.out.Flush()
+}
+
+func (w *pdfWriter) writePage() {
+	var content strings.Builder
+	fmt.Fprintf(&content, "BT\n%d TL\n%d %d Td\n", pdfLeading, pdfMargin, pdfPageHeight-pdfMargin)
+	for _, line := range w.lines {
+		font := "/F1"
+		if line.bold {
+			font = "/F2"
+		}
+		fmt.Fprintf(&content, "%s %d Tf\n(%s) Tj T*\n", font, pdfFontSize, pdfEscape(line.text))
+	}
+	fmt.Fprintf(&content, "ET\nBT\n/F1 %d Tf\n%d %d Td\n(Page %d) Tj\nET\n", pdfFontSize, pdfMargin, pdfMargin/2, len(w.pages)+1)
+	w.lines = w.lines[:0]
+
+	contents := w.allocate()
+	w.object(contents, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+
+	page := w.allocate()
+	w.object(page, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] "+
+		"/Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfFontObject, pdfBoldFontObject, contents))
+	w.pages = append(w.pages, page)
+}
+
+func (w *pdfWriter) allocate() int {
+	object := w.next
+	w.next++
+	return object
+}
+
+func (w *pdfWriter) object(number int, body string) {
+	w.offsets[number] = w.written
+	w.write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", number, body))
+}
+
+// write копит смещение в байтах: по нему строится таблица xref. Ошибку записи
+// bufio.Writer запоминает и возвращает из ближайшего Flush.
+func (w *pdfWriter) write(s string) {
+	n, _ := w.out.WriteString(s)
+	w.written += int64(n)
+}
+
+func pdfRow(date, operation, transaction, amount, balance string) string {
+	return fmt.Sprintf("%-19s %-12s %-36s %18s %18s", date, operation, transaction, amount, balance)
+}
+
+// pdfEscape экранирует строку PDF и заменяет символы вне печатного ASCII.
+func pdfEscape(text string) string {
+	var escaped strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			escaped.WriteByte('\\')
+			escaped.WriteRune(r)
+		case r < ' ' || r > '~':
+			escaped.WriteByte('?')
+		default:
+			escaped.WriteRune(r)
+		}
+	}
+	return escaped.String()
+}
@@ -0,0 +1,60 @@
+package statement
+
+import (
+	"io"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/shopspring/decimal"
+)
+
+// timeLayout — формат дат в CSV и PDF; все даты выписки выводятся в UTC.
+const timeLayout = "2006-01-02 15:04:05"
+
+// NewWriter возвращает StatementWriter для формата. Писатели буферизуют вывод и сбрасывают
+// его по мере заполнения буфера, поэтому выписка любого размера не собирается в памяти.
+func NewWriter(format model.StatementFormat, w io.Writer) model.StatementWriter {
+	switch format {
+	case model.StatementCSV:
+		return newCSVWriter(w)
+	case model.StatementPDF:
+		return newPDFWriter(w)
+	default:
+		return newJSONWriter(w)
+	}
+}
+
+// ContentType — MIME-тип и расширение файла выписки.
+func ContentType(format model.StatementFormat) (string, string) {
+	switch format {
+	case model.StatementCSV:
+		return "text/csv", "csv"
+	case model.StatementPDF:
+		return "application/pdf", "pdf"
+	default:
+		return "application/json", "json"
+	}
+}
+
+// formatAmount выводит сумму с точностью валюты кошелька.
+func formatAmount(amount decimal.Decimal, currency string) string {
+	if c, ok := model.LookupCurrency(currency); ok {
+		return amount.StringFixed(c.Precision)
+	}
+	return amount.String()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// reference — связанная операция: перевод, обмен или отменяемая транзакция.
+func reference(record model.TransactionRecord) string {
+	switch {
+	case record.TransferID != nil:
+		return record.TransferID.String()
+	case record.ReversalOf != nil:
+		return record.ReversalOf.String()
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dannamer/JavaCode-test/internal/model"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var from = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func testStatement(lines int) (model.Statement, []model.StatementLine) {
+	header := model.Statement{
+		WalletID:         uuid.New(),
+		Currency:         "RUB",
+		From:             from,
+		To:               from.AddDate(0, 1, 0),
+		OpeningBalance:   decimal.NewFromInt32(100),
+		TotalCredits:     decimal.NewFromInt32(int32(lines)),
+		ClosingBalance:   decimal.NewFromInt32(100 + int32(lines)),
+		TransactionCount: int64(lines),
+		GeneratedAt:      from.AddDate(0, 2, 0),
+	}
+	statementLines := make([]model.StatementLine, 0, lines)
+	for i := 1; i <= lines; i++ {
+		statementLines = append(statementLines, model.StatementLine{
+			TransactionRecord: model.TransactionRecord{
+				UUID:          uuid.New(),
+				WalletID:      header.WalletID,
+				OperationType: model.Deposit,
+				Amount:        decimal.NewFromInt32(1),
+				Currency:      "RUB",
+				CreatedAt:     from.Add(time.Duration(i) * time.Minute),
+			},
+			Balance: decimal.NewFromInt32(100 + int32(i)),
+		})
+	}
+	return header, statementLines
+}
+
+func write(t *testing.T, format model.StatementFormat, header model.Statement, lines []model.StatementLine) []byte {
+	var out bytes.Buffer
+	writer := NewWriter(format, &out)
+	assert.NoError(t, writer.Begin(header))
+	for _, line := range lines {
+		assert.NoError(t, writer.Line(line))
+	}
+	assert.NoError(t, writer.End(header))
+	return out.Bytes()
+}
+
+func TestCSVWriter(t *testing.T) {
+	header, lines := testStatement(1)
+	lines[0].OperationType = model.Withdraw
+	lines[0].Balance = decimal.NewFromInt32(99)
+
+	records, err := csv.NewReader(bytes.NewReader(write(t, model.StatementCSV, header, lines))).ReadAll()
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"date", "type", "transaction_uuid", "amount", "balance", "currency", "reference"},
+		{"2024-03-01 00:00:00", openingBalanceRow, "", "", "100.00", "RUB", ""},
+		{"2024-03-01 00:01:00", "WITHDRAW", lines[0].UUID.String(), "-1.00", "99.00", "RUB", ""},
+		{"2024-04-01 00:00:00", closingBalanceRow, "", "", "101.00", "RUB", ""},
+	}, records)
+}
+
+func TestJSONWriter(t *testing.T) {
+	header, lines := testStatement(2)
+
+	var decoded struct {
+		Statement    model.Statement       `json:"statement"`
+		Transactions []model.StatementLine `json:"transactions"`
+	}
+	err := json.Unmarshal(write(t, model.StatementJSON, header, lines), &decoded)
+
+	assert.NoError(t, err)
+	assert.Equal(t, header.WalletID, decoded.Statement.WalletID)
+	assert.Len(t, decoded.Transactions, 2)
+	assert.True(t, decimal.NewFromInt32(102).Equal(decoded.Transactions[1].Balance))
+}
+
+func TestJSONWriter_NoTransactions(t *testing.T) {
+	header, _ := testStatement(0)
+
+	var decoded map[string]json.RawMessage
+	err := json.Unmarshal(write(t, model.StatementJSON, header, nil), &decoded)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(decoded["transactions"]))
+}
+
+func TestPDFWriter_XrefPointsAtObjects(t *testing.T) {
+	header, lines := testStatement(3 * pdfLinesPerPage)
+	owner := "Иван (main)"
+	header.OwnerID = &owner
+
+	pdf := write(t, model.StatementPDF, header, lines)
+
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), `Owner:           ???? \(main\)`)
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	assert.NotNil(t, startxref)
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	assert.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(pdf[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(pdf[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+
+	// Заголовок, три страницы операций и итоги не помещаются на три страницы.
+	assert.Contains(t, string(pdf), "/Count 4 >>")
+	assert.Equal(t, 4, strings.Count(string(pdf), "(Date \\(UTC\\)"))
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func useInMemoryExporter(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return exporter
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), Config{Exporter: "jaeger", ServiceName: "wallet"})
+	assert.Error(t, err)
+}
+
+func TestSetup_None(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterNone, ServiceName: "wallet"})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestQueryTracer(t *testing.T) {
+	exporter := useInMemoryExporter(t)
+	tracer := QueryTracer{}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("boom")})
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "db.query", spans[0].Name)
+	assert.Contains(t, spans[0].Attributes, semconv.DBQueryText("SELECT 1"))
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
+
+func TestTraceparentPropagation(t *testing.T) {
+	exporter := useInMemoryExporter(t)
+	_, err := Setup(context.Background(), Config{Exporter: ExporterNone})
+	assert.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Use(otelmux.Middleware("wallet"))
+	router.HandleFunc("/api/v1/wallet", func(w http.ResponseWriter, r *http.Request) {
+		_, span := Tracer().Start(r.Context(), "WalletService.WalletTransaction")
+		span.End()
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	service, server := spans[0], spans[1]
+	assert.Equal(t, "/api/v1/wallet", server.Name)
+	assert.Equal(t, traceID, server.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	assert.Equal(t, server.SpanContext.SpanID(), service.Parent.SpanID())
+}
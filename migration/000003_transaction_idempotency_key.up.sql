@@ -0,0 +1,3 @@
+ALTER TABLE transactions ADD COLUMN idempotency_key VARCHAR(255);
+
+CREATE UNIQUE INDEX transactions_idempotency_key_idx ON transactions (idempotency_key);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS transactions_wallet_created_idx;
@@ -0,0 +1 @@
+CREATE INDEX transactions_wallet_created_idx ON transactions (wallet_uuid, created_at, uuid);
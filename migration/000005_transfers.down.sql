@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS transactions_transfer_uuid_idx;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_uuid;
+
+-- Строки TRANSFER_OUT и TRANSFER_IN остаются: они уже учтены в wallets.balance, и без них
+-- журнал разошёлся бы с балансами. Поэтому и transaction_type не сужается до VARCHAR(10).
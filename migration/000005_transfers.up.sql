@@ -0,0 +1,5 @@
+ALTER TABLE transactions ALTER COLUMN transaction_type TYPE VARCHAR(20);
+
+ALTER TABLE transactions ADD COLUMN transfer_uuid UUID;
+
+CREATE INDEX transactions_transfer_uuid_idx ON transactions (transfer_uuid);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS ledger_entries;
+
+DROP FUNCTION IF EXISTS ledger_entries_balanced();
+
+DROP FUNCTION IF EXISTS ledger_entries_append_only();
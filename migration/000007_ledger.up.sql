@@ -0,0 +1,70 @@
+CREATE TABLE ledger_entries (
+    id BIGSERIAL PRIMARY KEY,
+    posting_uuid UUID NOT NULL,
+    transaction_uuid UUID REFERENCES transactions(uuid),
+    account VARCHAR(64) NOT NULL,
+    side VARCHAR(6) NOT NULL CHECK (side IN ('DEBIT', 'CREDIT')),
+    amount DECIMAL(20, 4) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX ledger_entries_account_idx ON ledger_entries (account, id);
+
+CREATE INDEX ledger_entries_posting_idx ON ledger_entries (posting_uuid);
+
+-- Перенос истории: депозиты и списания проводятся против кассы, переводы группируются по transfer_uuid.
+INSERT INTO ledger_entries (posting_uuid, transaction_uuid, account, side, amount, created_at)
+SELECT COALESCE(transfer_uuid, uuid), uuid, 'wallet:' || wallet_uuid,
+       CASE WHEN transaction_type IN ('DEPOSIT', 'TRANSFER_IN') THEN 'CREDIT' ELSE 'DEBIT' END,
+       amount, created_at
+FROM transactions
+WHERE amount > 0;
+
+INSERT INTO ledger_entries (posting_uuid, account, side, amount, created_at)
+SELECT uuid, 'system:cash',
+       CASE WHEN transaction_type = 'DEPOSIT' THEN 'DEBIT' ELSE 'CREDIT' END,
+       amount, created_at
+FROM transactions
+WHERE transaction_type IN ('DEPOSIT', 'WITHDRAW') AND amount > 0;
+
+-- Балансы, появившиеся до журнала транзакций, фиксируются входящим остатком.
+WITH opening AS (
+    SELECT w.uuid, w.balance - COALESCE(SUM(CASE
+               WHEN t.transaction_type IN ('DEPOSIT', 'TRANSFER_IN') THEN t.amount
+               ELSE -t.amount END), 0) AS amount
+    FROM wallets w
+    LEFT JOIN transactions t ON t.wallet_uuid = w.uuid
+    GROUP BY w.uuid, w.balance
+), postings AS (
+    SELECT gen_random_uuid() AS posting_uuid, uuid, amount FROM opening WHERE amount <> 0
+)
+INSERT INTO ledger_entries (posting_uuid, account, side, amount)
+SELECT posting_uuid, 'wallet:' || uuid, CASE WHEN amount > 0 THEN 'CREDIT' ELSE 'DEBIT' END, ABS(amount) FROM postings
+UNION ALL
+SELECT posting_uuid, 'system:opening', CASE WHEN amount > 0 THEN 'DEBIT' ELSE 'CREDIT' END, ABS(amount) FROM postings;
+
+CREATE FUNCTION ledger_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_entries_append_only
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION ledger_entries_append_only();
+
+-- Проверка выполняется при COMMIT, когда все проводки операции уже вставлены.
+CREATE FUNCTION ledger_entries_balanced() RETURNS trigger AS $$
+BEGIN
+    IF (SELECT SUM(CASE side WHEN 'DEBIT' THEN amount ELSE -amount END)
+        FROM ledger_entries WHERE posting_uuid = NEW.posting_uuid) <> 0 THEN
+        RAISE EXCEPTION 'ledger posting % is not balanced', NEW.posting_uuid;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER ledger_entries_balanced
+    AFTER INSERT ON ledger_entries
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION ledger_entries_balanced();
@@ -0,0 +1,19 @@
+CREATE TABLE reconciliation_runs (
+    uuid UUID PRIMARY KEY,
+    started_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP NOT NULL,
+    wallets_checked INTEGER NOT NULL,
+    mismatches INTEGER NOT NULL
+);
+
+CREATE TABLE reconciliation_findings (
+    id BIGSERIAL PRIMARY KEY,
+    run_uuid UUID NOT NULL REFERENCES reconciliation_runs(uuid) ON DELETE CASCADE,
+    wallet_uuid UUID NOT NULL,
+    balance DECIMAL(20, 4) NOT NULL,
+    transactions_balance DECIMAL(20, 4) NOT NULL,
+    ledger_balance DECIMAL(20, 4) NOT NULL,
+    drift DECIMAL(20, 4) NOT NULL
+);
+
+CREATE INDEX reconciliation_findings_run_idx ON reconciliation_findings (run_uuid);
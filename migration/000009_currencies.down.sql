@@ -0,0 +1,28 @@
+CREATE OR REPLACE FUNCTION ledger_entries_balanced() RETURNS trigger AS $$
+BEGIN
+    IF (SELECT SUM(CASE side WHEN 'DEBIT' THEN amount ELSE -amount END)
+        FROM ledger_entries WHERE posting_uuid = NEW.posting_uuid) <> 0 THEN
+        RAISE EXCEPTION 'ledger posting % is not balanced', NEW.posting_uuid;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE reconciliation_findings
+    ALTER COLUMN balance TYPE DECIMAL(20, 4),
+    ALTER COLUMN transactions_balance TYPE DECIMAL(20, 4),
+    ALTER COLUMN ledger_balance TYPE DECIMAL(20, 4),
+    ALTER COLUMN drift TYPE DECIMAL(20, 4);
+
+ALTER TABLE ledger_entries
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN amount TYPE DECIMAL(20, 4);
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN amount TYPE DECIMAL(20, 4),
+    ALTER COLUMN balance_after TYPE DECIMAL(20, 4);
+
+ALTER TABLE wallets
+    DROP COLUMN IF EXISTS currency,
+    ALTER COLUMN balance TYPE DECIMAL(20, 4);
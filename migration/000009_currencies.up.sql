@@ -0,0 +1,30 @@
+ALTER TABLE wallets
+    ALTER COLUMN balance TYPE DECIMAL(38, 18),
+    ADD COLUMN currency VARCHAR(10) NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE DECIMAL(38, 18),
+    ALTER COLUMN balance_after TYPE DECIMAL(38, 18),
+    ADD COLUMN currency VARCHAR(10) NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE ledger_entries
+    ALTER COLUMN amount TYPE DECIMAL(38, 18),
+    ADD COLUMN currency VARCHAR(10) NOT NULL DEFAULT 'RUB';
+
+ALTER TABLE reconciliation_findings
+    ALTER COLUMN balance TYPE DECIMAL(38, 18),
+    ALTER COLUMN transactions_balance TYPE DECIMAL(38, 18),
+    ALTER COLUMN ledger_balance TYPE DECIMAL(38, 18),
+    ALTER COLUMN drift TYPE DECIMAL(38, 18);
+
+-- Проводка должна сходиться в каждой валюте отдельно.
+CREATE OR REPLACE FUNCTION ledger_entries_balanced() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM ledger_entries WHERE posting_uuid = NEW.posting_uuid
+               GROUP BY currency
+               HAVING SUM(CASE side WHEN 'DEBIT' THEN amount ELSE -amount END) <> 0) THEN
+        RAISE EXCEPTION 'ledger posting % is not balanced', NEW.posting_uuid;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS exchange_spread,
+    DROP COLUMN IF EXISTS exchange_rate;
@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    ADD COLUMN exchange_rate DECIMAL(38, 18),
+    ADD COLUMN exchange_spread DECIMAL(10, 8);
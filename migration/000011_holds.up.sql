@@ -0,0 +1,19 @@
+ALTER TABLE wallets ADD COLUMN held DECIMAL(38, 18) NOT NULL DEFAULT 0;
+
+CREATE TABLE holds (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wallet_uuid UUID NOT NULL REFERENCES wallets(uuid),
+    amount DECIMAL(38, 18) NOT NULL CHECK (amount > 0),
+    captured_amount DECIMAL(38, 18),
+    currency VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    transaction_uuid UUID REFERENCES transactions(uuid),
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX holds_wallet_idx ON holds (wallet_uuid);
+
+-- Воркер истечения выбирает только активные холды.
+CREATE INDEX holds_active_expires_idx ON holds (expires_at) WHERE status = 'ACTIVE';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS wallet_status_audit;
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS status;
@@ -0,0 +1,14 @@
+ALTER TABLE wallets ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE'
+    CHECK (status IN ('ACTIVE', 'FROZEN', 'CLOSED'));
+
+CREATE TABLE wallet_status_audit (
+    id BIGSERIAL PRIMARY KEY,
+    wallet_uuid UUID NOT NULL REFERENCES wallets(uuid),
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX wallet_status_audit_wallet_idx ON wallet_status_audit (wallet_uuid, created_at);
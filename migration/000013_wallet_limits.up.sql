@@ -0,0 +1,11 @@
+-- NULL в столбце означает, что действует лимит по умолчанию из конфигурации.
+CREATE TABLE wallet_limits (
+    wallet_uuid UUID PRIMARY KEY REFERENCES wallets(uuid),
+    max_operation DECIMAL(38, 18) CHECK (max_operation >= 0),
+    max_balance DECIMAL(38, 18) CHECK (max_balance >= 0),
+    daily_deposit DECIMAL(38, 18) CHECK (daily_deposit >= 0),
+    daily_withdrawal DECIMAL(38, 18) CHECK (daily_withdrawal >= 0),
+    monthly_deposit DECIMAL(38, 18) CHECK (monthly_deposit >= 0),
+    monthly_withdrawal DECIMAL(38, 18) CHECK (monthly_withdrawal >= 0),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
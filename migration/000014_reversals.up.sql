@@ -0,0 +1,3 @@
+ALTER TABLE transactions ADD COLUMN reversal_of UUID REFERENCES transactions(uuid);
+
+CREATE INDEX transactions_reversal_of_idx ON transactions (reversal_of) WHERE reversal_of IS NOT NULL;
@@ -0,0 +1,10 @@
+-- Снимок хранит баланс с учётом всех операций с created_at строго раньше snapshot_at.
+-- snapshot_at, как и created_at, — время UTC без зоны: сервис открывает сессии с timezone = UTC,
+-- поэтому CURRENT_TIMESTAMP и полночи снимков, переданные из Go, сравнимы.
+CREATE TABLE wallet_balance_snapshots (
+    wallet_uuid UUID NOT NULL REFERENCES wallets(uuid),
+    snapshot_at TIMESTAMP NOT NULL,
+    balance DECIMAL(38, 18) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (wallet_uuid, snapshot_at)
+);